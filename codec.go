@@ -0,0 +1,91 @@
+package paginate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CursorCodec serializes CursorData to and from bytes before base64 encoding.
+// Implement it to use a more compact format such as MessagePack.
+type CursorCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// CursorFormatJSON is the format marker of the default JSON codec. JSON
+// cursors carry no extra marker byte: the opening brace of the object serves
+// as one, so cursors encoded before codecs were configurable still decode.
+const CursorFormatJSON byte = '{'
+
+// CursorFormat selects the codec used to encode new cursors. Its codec must
+// have been registered with RegisterCursorCodec. Cursors of any registered
+// format are decoded regardless of this setting, so switching formats does
+// not break cursors already handed out.
+// It should only be set during program initialization.
+var CursorFormat = CursorFormatJSON
+
+// cursorCodecs maps format marker bytes to codecs.
+var cursorCodecs = map[byte]CursorCodec{CursorFormatJSON: jsonCodec{}}
+
+// RegisterCursorCodec registers a codec under a format marker byte, which is
+// prepended to every cursor it encodes so decoders can tell formats apart:
+//
+//	func init() {
+//		paginate.RegisterCursorCodec('m', msgpackCodec{})
+//		paginate.CursorFormat = 'm'
+//	}
+//
+// It panics if codec is nil, the format is already registered or reserved
+// for secure cursors (0x90-0x9f, see EncodeSecureCursor), and should only be
+// called during program initialization.
+func RegisterCursorCodec(format byte, codec CursorCodec) {
+	if codec == nil {
+		panic("paginate: RegisterCursorCodec codec is nil")
+	}
+	if format&secureVersionMask == secureCursorVersion {
+		panic(fmt.Sprintf("paginate: RegisterCursorCodec format %#x is reserved for secure cursors", format))
+	}
+	if _, dup := cursorCodecs[format]; dup {
+		panic(fmt.Sprintf("paginate: RegisterCursorCodec called twice for format %q", format))
+	}
+	cursorCodecs[format] = codec
+}
+
+// jsonCodec is the default CursorCodec.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// marshalCursor serializes v with the codec selected by CursorFormat,
+// prefixing the format marker for non-JSON codecs.
+func marshalCursor(v any) ([]byte, error) {
+	codec, ok := cursorCodecs[CursorFormat]
+	if !ok {
+		return nil, fmt.Errorf("paginate: no cursor codec registered for format %q", CursorFormat)
+	}
+	b, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if CursorFormat == CursorFormatJSON {
+		return b, nil
+	}
+	return append([]byte{CursorFormat}, b...), nil
+}
+
+// unmarshalCursor deserializes a cursor payload with the codec identified by
+// its first byte. Returns ErrInvalidCursor for unknown formats.
+func unmarshalCursor(b []byte, v any) error {
+	if len(b) == 0 {
+		return ErrInvalidCursor
+	}
+	if b[0] == CursorFormatJSON {
+		return json.Unmarshal(b, v)
+	}
+	codec, ok := cursorCodecs[b[0]]
+	if !ok {
+		return ErrInvalidCursor
+	}
+	return codec.Unmarshal(b[1:], v)
+}
@@ -0,0 +1,112 @@
+package paginate
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// reverseCodec is a stand-in for a compact codec such as MessagePack.
+type reverseCodec struct{}
+
+func (reverseCodec) Marshal(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return reverse(b), nil
+}
+
+func (reverseCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(reverse(data), v)
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[len(b)-1-i] = c
+	}
+	return out
+}
+
+func useTestCodec(t *testing.T) {
+	t.Helper()
+	if _, ok := cursorCodecs['r']; !ok {
+		RegisterCursorCodec('r', reverseCodec{})
+	}
+	CursorFormat = 'r'
+	t.Cleanup(func() { CursorFormat = CursorFormatJSON })
+}
+
+func TestCursorCodec(t *testing.T) {
+	jsonCursor, err := EncodeCursor(&CursorData[any]{ID: "42"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	useTestCodec(t)
+
+	cursor, err := EncodeCursor(&CursorData[any]{ID: "42", Offset: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	raw, _ := DecodeCursorRaw(cursor)
+	if raw[0] != 'r' {
+		t.Errorf("Expected format marker 'r', got %q", raw[0])
+	}
+
+	data, err := DecodeCursor[any](cursor)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	if data.ID != "42" || data.Offset != 7 {
+		t.Errorf("Unexpected data: %+v", data)
+	}
+
+	// JSON cursors issued before the switch still decode.
+	data, err = DecodeCursor[any](jsonCursor)
+	if err != nil || data.ID != "42" {
+		t.Errorf("Expected JSON cursor to decode, got %+v, %v", data, err)
+	}
+
+	batch, err := EncodeCursors([]*CursorData[any]{{ID: "42", Offset: 7}, nil})
+	if err != nil {
+		t.Fatalf("Unexpected batch error: %v", err)
+	}
+	if batch[0] != cursor || batch[1] != "" {
+		t.Errorf("Expected batch to match EncodeCursor, got %v", batch)
+	}
+}
+
+func TestCursorCodecErrors(t *testing.T) {
+	if _, err := DecodeCursor[any](EncodeCursorRaw([]byte("?payload"))); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for unknown format, got %v", err)
+	}
+
+	CursorFormat = 'z'
+	defer func() { CursorFormat = CursorFormatJSON }()
+	if _, err := EncodeCursor(&CursorData[any]{ID: "1"}); err == nil {
+		t.Error("Expected error for unregistered format")
+	}
+}
+
+func TestRegisterCursorCodecPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		format byte
+	}{
+		{"JSON format", CursorFormatJSON},
+		{"Secure cursor header", 0x93},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic when registering format %#x", tt.format)
+				}
+			}()
+			RegisterCursorCodec(tt.format, reverseCodec{})
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package paginate
+
+import (
+	"context"
+	"fmt"
+)
+
+// CursorCollect walks a cursor-paginated source from start, following each
+// page's NextCursor until HasMore is false, and returns all items in one
+// slice. It is meant for tests and small datasets.
+//
+// maxItems caps the number of items collected so runaway walks are caught:
+// if the source holds more than maxItems items, the first maxItems items are
+// returned together with ErrResultCapExceeded. A nil start begins at the
+// first page with the default limit. Returns ErrInvalidPageSize if
+// maxItems < 1, ErrNilPage if fetch returns a nil page without an error,
+// ErrInvalidCursor if a page reports HasMore without a NextCursor, and the
+// context's error if ctx is done between fetches. Errors from fetch are
+// returned unchanged along with the items collected so far.
+func CursorCollect[T any](ctx context.Context, start *CursorPaginator, maxItems int, fetch func(context.Context, *CursorPaginator) (*CursorPage[T], error)) ([]T, error) {
+	if maxItems < 1 {
+		return nil, fmt.Errorf("%w: maxItems must be >= 1, got %d", ErrInvalidPageSize, maxItems)
+	}
+	c := start
+	if c == nil {
+		c = NewCursor()
+	}
+
+	var items []T
+	for {
+		if err := ctx.Err(); err != nil {
+			return items, err
+		}
+		page, err := fetch(ctx, c)
+		if err != nil {
+			return items, err
+		}
+		if page == nil {
+			return items, ErrNilPage
+		}
+
+		if len(items)+len(page.Items) > maxItems {
+			items = append(items, page.Items[:maxItems-len(items)]...)
+			return items, fmt.Errorf("%w: more than %d items", ErrResultCapExceeded, maxItems)
+		}
+		items = append(items, page.Items...)
+
+		if !page.HasMore {
+			return items, nil
+		}
+		if len(items) == maxItems {
+			return items, fmt.Errorf("%w: more than %d items", ErrResultCapExceeded, maxItems)
+		}
+		if page.NextCursor == "" {
+			return items, fmt.Errorf("%w: page has more items but no next cursor", ErrInvalidCursor)
+		}
+		c = c.WithCursor(page.NextCursor).WithForward(true)
+	}
+}
@@ -0,0 +1,112 @@
+package paginate
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// sliceFetcher serves items from a slice using offset cursors.
+func sliceFetcher(items []int) func(context.Context, *CursorPaginator) (*CursorPage[int], error) {
+	return func(_ context.Context, c *CursorPaginator) (*CursorPage[int], error) {
+		data, err := c.Decode()
+		if err != nil {
+			return nil, err
+		}
+		start := 0
+		if data != nil {
+			start = data.Offset
+		}
+		end := min(start+c.Limit, len(items))
+		var next string
+		if end < len(items) {
+			next, _ = NewCursorFromOffset(end)
+		}
+		return NewCursorPageSimple(items[start:end], c.Limit, next), nil
+	}
+}
+
+func TestCursorCollect(t *testing.T) {
+	source := make([]int, 25)
+	for i := range source {
+		source[i] = i
+	}
+
+	tests := []struct {
+		name     string
+		max      int
+		expected int
+		err      error
+	}{
+		{"Drains source", 100, 25, nil},
+		{"Exact cap", 25, 25, nil},
+		{"Cap mid-page", 12, 12, ErrResultCapExceeded},
+		{"Cap on page boundary", 20, 20, ErrResultCapExceeded},
+		{"Invalid cap", 0, 0, ErrInvalidPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := CursorCollect(context.Background(), NewCursorWithLimit(10), tt.max, sliceFetcher(source))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if len(items) != tt.expected {
+				t.Fatalf("Expected %d items, got %d", tt.expected, len(items))
+			}
+			for i, v := range items {
+				if v != i {
+					t.Fatalf("Expected item %d at index %d, got %d", i, i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCursorCollectErrors(t *testing.T) {
+	t.Run("Missing next cursor", func(t *testing.T) {
+		fetch := func(context.Context, *CursorPaginator) (*CursorPage[int], error) {
+			return NewCursorPage([]int{1}, 1, "", "", true), nil
+		}
+		_, err := CursorCollect(context.Background(), nil, 10, fetch)
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+
+	t.Run("Nil page", func(t *testing.T) {
+		fetch := func(context.Context, *CursorPaginator) (*CursorPage[int], error) {
+			return nil, nil
+		}
+		_, err := CursorCollect(context.Background(), nil, 10, fetch)
+		if !errors.Is(err, ErrNilPage) {
+			t.Errorf("Expected ErrNilPage, got %v", err)
+		}
+	})
+
+	t.Run("Fetch error", func(t *testing.T) {
+		boom := errors.New("boom")
+		calls := 0
+		fetch := func(context.Context, *CursorPaginator) (*CursorPage[int], error) {
+			calls++
+			if calls > 1 {
+				return nil, boom
+			}
+			return NewCursorPageSimple([]int{1}, 1, strconv.Itoa(calls)), nil
+		}
+		items, err := CursorCollect(context.Background(), nil, 10, fetch)
+		if !errors.Is(err, boom) || len(items) != 1 {
+			t.Errorf("Expected fetch error after 1 item, got %v (%d items)", err, len(items))
+		}
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := CursorCollect(ctx, nil, 10, sliceFetcher([]int{1, 2, 3}))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
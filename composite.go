@@ -0,0 +1,268 @@
+package paginate
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// typedKeysetCursor is the wire format of EncodeKeysetCursorTyped. Values
+// are stored under their own key, so DecodeKeysetCursor rejects typed
+// cursors instead of misreading their tagged values.
+type typedKeysetCursor struct {
+	Fields      []string             `json:"f"`
+	Values      []compositeComponent `json:"t"`
+	SkipSameKey int                  `json:"s,omitempty"`
+}
+
+// compositeComponent is the wire format of a single typed keyset value.
+// The type tag lets DecodeKeysetCursorTyped restore the exact Go type, so
+// integers keep full precision and timestamps compare chronologically.
+type compositeComponent struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// Compare compares the Values of kc with those of other component by
+// component, honoring the direction of the matching sort field, for keyset
+// pagination over mixed-type composite keys, e.g. (score, username). Values
+// may be integers, floats, strings, bools, time.Time or nil. It returns -1
+// if kc sorts before other, 1 if after and 0 if they are equal.
+//
+// Strings are compared bytewise, so the result only agrees with the
+// database's ORDER BY under a binary collation (e.g. COLLATE "C" in
+// PostgreSQL, utf8mb4_bin in MySQL). Under a linguistic collation rows may be
+// skipped or repeated; sort such columns with an explicit binary collation.
+// Returns ErrInvalidCursor if the values and sort differ in length or a pair
+// of values cannot be compared.
+func (kc *KeysetCursor) Compare(other *KeysetCursor, sort Sort) (int, error) {
+	if len(kc.Values) != len(sort) || len(other.Values) != len(sort) {
+		return 0, fmt.Errorf("%w: keyset cursors have %d and %d values for %d sort fields",
+			ErrInvalidCursor, len(kc.Values), len(other.Values), len(sort))
+	}
+
+	for i, field := range sort {
+		c, err := compareComponent(kc.Values[i], other.Values[i])
+		if err != nil {
+			return 0, fmt.Errorf("%w: field %q: %v", ErrInvalidCursor, field.Field, err)
+		}
+		if field.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+// After returns true if kc sorts strictly after other under sort.
+func (kc *KeysetCursor) After(other *KeysetCursor, sort Sort) (bool, error) {
+	c, err := kc.Compare(other, sort)
+	return c > 0, err
+}
+
+// Before returns true if kc sorts strictly before other under sort.
+func (kc *KeysetCursor) Before(other *KeysetCursor, sort Sort) (bool, error) {
+	c, err := kc.Compare(other, sort)
+	return c < 0, err
+}
+
+// EncodeKeysetCursorTyped encodes a keyset cursor like EncodeKeysetCursor,
+// but tags each value with its type so DecodeKeysetCursorTyped restores it
+// exactly, instead of the float64 and string values plain JSON yields.
+// Returns an empty string and nil error if kc is nil, or an error if a value
+// has an unsupported type.
+func EncodeKeysetCursorTyped(kc *KeysetCursor) (string, error) {
+	if kc == nil {
+		return "", nil
+	}
+	if len(kc.Fields) != len(kc.Values) {
+		return "", fmt.Errorf("%w: got %d fields and %d values",
+			ErrInvalidCursor, len(kc.Fields), len(kc.Values))
+	}
+
+	components := make([]compositeComponent, len(kc.Values))
+	for i, v := range kc.Values {
+		c, err := encodeComponent(v)
+		if err != nil {
+			return "", err
+		}
+		components[i] = c
+	}
+
+	b, err := json.Marshal(typedKeysetCursor{Fields: kc.Fields, Values: components, SkipSameKey: kc.SkipSameKey})
+	if err != nil {
+		return "", err
+	}
+	return checkCursorSize(EncodeCursorRaw(b))
+}
+
+// DecodeKeysetCursorTyped decodes a cursor produced by
+// EncodeKeysetCursorTyped. Integers decode as int64 or uint64, floats as
+// float64 and timestamps as UTC time.Time.
+// Returns an error if the cursor is malformed.
+func DecodeKeysetCursorTyped(cursor string) (*KeysetCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	b, err := DecodeCursorRaw(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var tc typedKeysetCursor
+	if err := json.Unmarshal(b, &tc); err != nil || len(tc.Fields) != len(tc.Values) {
+		return nil, ErrInvalidCursor
+	}
+
+	kc := &KeysetCursor{Fields: tc.Fields, Values: make([]any, len(tc.Values)), SkipSameKey: tc.SkipSameKey}
+	for i, c := range tc.Values {
+		v, err := decodeComponent(c)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		kc.Values[i] = v
+	}
+	return kc, nil
+}
+
+// encodeComponent converts a keyset value to its tagged wire format.
+func encodeComponent(v any) (compositeComponent, error) {
+	switch v := normalizeComponent(v).(type) {
+	case nil:
+		return compositeComponent{Type: "n"}, nil
+	case int64:
+		return compositeComponent{Type: "i", Value: strconv.FormatInt(v, 10)}, nil
+	case uint64:
+		return compositeComponent{Type: "u", Value: strconv.FormatUint(v, 10)}, nil
+	case float64:
+		return compositeComponent{Type: "f", Value: strconv.FormatFloat(v, 'g', -1, 64)}, nil
+	case string:
+		return compositeComponent{Type: "s", Value: v}, nil
+	case bool:
+		return compositeComponent{Type: "b", Value: strconv.FormatBool(v)}, nil
+	case time.Time:
+		return compositeComponent{Type: "t", Value: v.Format(time.RFC3339Nano)}, nil
+	default:
+		return compositeComponent{}, fmt.Errorf("%w: unsupported keyset value type %T",
+			ErrInvalidCursor, v)
+	}
+}
+
+// decodeComponent restores a keyset value from its tagged wire format.
+func decodeComponent(c compositeComponent) (any, error) {
+	switch c.Type {
+	case "n":
+		return nil, nil
+	case "i":
+		return strconv.ParseInt(c.Value, 10, 64)
+	case "u":
+		return strconv.ParseUint(c.Value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(c.Value, 64)
+	case "s":
+		return c.Value, nil
+	case "b":
+		return strconv.ParseBool(c.Value)
+	case "t":
+		return time.Parse(time.RFC3339Nano, c.Value)
+	default:
+		return nil, fmt.Errorf("unknown component type %q", c.Type)
+	}
+}
+
+// normalizeComponent widens numeric types to int64, uint64 or float64 and
+// normalizes timestamps, so comparison only deals with a few types.
+func normalizeComponent(v any) any {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return normalizeTimestamp(v)
+	default:
+		return v
+	}
+}
+
+// compareComponent compares two keyset values of compatible types.
+// Integers and floats compare numerically with each other, and strings
+// bytewise (binary collation).
+func compareComponent(a, b any) (int, error) {
+	a, b = normalizeComponent(a), normalizeComponent(b)
+
+	switch a := a.(type) {
+	case nil:
+		if b == nil {
+			return 0, nil
+		}
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, b), nil
+		case uint64:
+			if a < 0 {
+				return -1, nil
+			}
+			return cmp.Compare(uint64(a), b), nil
+		case float64:
+			return cmp.Compare(float64(a), b), nil
+		}
+	case uint64:
+		switch b := b.(type) {
+		case int64, float64:
+			c, err := compareComponent(b, a)
+			return -c, err
+		case uint64:
+			return cmp.Compare(a, b), nil
+		}
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, float64(b)), nil
+		case uint64:
+			return cmp.Compare(a, float64(b)), nil
+		case float64:
+			return cmp.Compare(a, b), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return cmp.Compare(a, b), nil
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, nil
+			case !a:
+				return -1, nil
+			default:
+				return 1, nil
+			}
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %T with %T", a, b)
+}
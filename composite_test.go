@@ -0,0 +1,168 @@
+package paginate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// scoreboard is sorted by ORDER BY score DESC, username COLLATE "C" ASC; the
+// binary collation sorts "Zed" before "carol".
+var scoreboard = []*KeysetCursor{
+	keyset(100, "alice"),
+	keyset(100, "bob"),
+	keyset(90, "Zed"),
+	keyset(90, "carol"),
+	keyset(42, "alice"),
+	keyset(7, "dave"),
+}
+
+var scoreboardSort = Sort{{Field: "score", Desc: true}, {Field: "username"}}
+
+// keyset builds a (score, username) keyset cursor.
+func keyset(score int, username string) *KeysetCursor {
+	return &KeysetCursor{Fields: []string{"score", "username"}, Values: []any{score, username}}
+}
+
+func TestKeysetCursorCompare(t *testing.T) {
+	shuffled := slices.Clone(scoreboard)
+	slices.Reverse(shuffled)
+	shuffled[0], shuffled[3] = shuffled[3], shuffled[0]
+
+	slices.SortFunc(shuffled, func(a, b *KeysetCursor) int {
+		c, err := a.Compare(b, scoreboardSort)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return c
+	})
+
+	for i := range scoreboard {
+		if shuffled[i] != scoreboard[i] {
+			t.Fatalf("Expected order %v, got %v", scoreboard, shuffled)
+		}
+	}
+}
+
+func TestKeysetCursorAfterCursor(t *testing.T) {
+	for i, last := range scoreboard {
+		cursor, err := EncodeKeysetCursorTyped(last)
+		if err != nil {
+			t.Fatalf("Unexpected encode error: %v", err)
+		}
+		decoded, err := DecodeKeysetCursorTyped(cursor)
+		if err != nil {
+			t.Fatalf("Unexpected decode error: %v", err)
+		}
+
+		var next []*KeysetCursor
+		for _, row := range scoreboard {
+			after, err := row.After(decoded, scoreboardSort)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if after {
+				next = append(next, row)
+			}
+		}
+		if len(next) != len(scoreboard)-i-1 {
+			t.Errorf("After %v: expected %d rows, got %d", last.Values, len(scoreboard)-i-1, len(next))
+		}
+
+		before, _ := scoreboard[0].Before(decoded, scoreboardSort)
+		if before != (i > 0) {
+			t.Errorf("Before %v: expected %v, got %v", last.Values, i > 0, before)
+		}
+	}
+}
+
+func TestKeysetCursorMixedNumbers(t *testing.T) {
+	asc := Sort{{Field: "n"}}
+	tests := []struct {
+		a, b     any
+		expected int
+	}{
+		{int64(-1), uint64(1), -1},
+		{uint64(1 << 63), int64(1), 1},
+		{uint64(3), uint64(2), 1},
+		{1.5, 1, 1},
+		{int32(2), 2.0, 0},
+		{uint8(1), 1.5, -1},
+	}
+
+	for _, tt := range tests {
+		a, b := &KeysetCursor{Values: []any{tt.a}}, &KeysetCursor{Values: []any{tt.b}}
+		c, err := a.Compare(b, asc)
+		if err != nil {
+			t.Fatalf("%v vs %v: unexpected error: %v", tt.a, tt.b, err)
+		}
+		if c != tt.expected {
+			t.Errorf("%v vs %v: expected %d, got %d", tt.a, tt.b, tt.expected, c)
+		}
+	}
+}
+
+func TestKeysetCursorCompareErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []any
+		sort Sort
+	}{
+		{"Length mismatch", []any{1}, []any{1, "a"}, scoreboardSort},
+		{"Type mismatch", []any{1, "a"}, []any{"1", "a"}, scoreboardSort},
+		{"Nil and value", []any{nil}, []any{1}, Sort{{Field: "n"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := &KeysetCursor{Values: tt.a}, &KeysetCursor{Values: tt.b}
+			if _, err := a.Compare(b, tt.sort); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestKeysetCursorTypedRoundTrip(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600, time.FixedZone("X", 3600))
+	key := &KeysetCursor{
+		Fields:      []string{"a", "b", "c", "d", "e", "f", "g"},
+		Values:      []any{int64(1<<62 + 1), uint64(1 << 63), 2.5, "bob", true, ts, nil},
+		SkipSameKey: 2,
+	}
+
+	cursor, err := EncodeKeysetCursorTyped(key)
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	kc, err := DecodeKeysetCursorTyped(cursor)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+
+	decoded := kc.Values
+	if len(decoded) != len(key.Values) || !slices.Equal(kc.Fields, key.Fields) || kc.SkipSameKey != 2 {
+		t.Fatalf("Expected %+v, got %+v", key, kc)
+	}
+	if decoded[0] != int64(1<<62+1) || decoded[1] != uint64(1<<63) || decoded[2] != 2.5 {
+		t.Errorf("Numbers did not round-trip exactly: %v", decoded)
+	}
+	if decoded[3] != "bob" || decoded[4] != true || decoded[6] != nil {
+		t.Errorf("Unexpected values: %v", decoded)
+	}
+	if got, ok := decoded[5].(time.Time); !ok || !got.Equal(ts) {
+		t.Errorf("Expected %v, got %v", ts, decoded[5])
+	}
+
+	unsupported := &KeysetCursor{Fields: []string{"x"}, Values: []any{struct{}{}}}
+	if _, err := EncodeKeysetCursorTyped(unsupported); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for unsupported type, got %v", err)
+	}
+	if _, err := DecodeKeysetCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected plain decoding of a typed cursor to fail, got %v", err)
+	}
+	if _, err := DecodeKeysetCursorTyped("not-valid!"); err == nil {
+		t.Error("Expected error for malformed cursor")
+	}
+}
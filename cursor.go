@@ -1,11 +1,17 @@
 package paginate
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,16 +22,84 @@ type CursorPaginator struct {
 	Cursor  string `json:"cursor,omitempty"`
 	Limit   int    `json:"limit"`
 	Forward bool   `json:"forward"` // true for next, false for previous
+
+	// Validator is an optional hook invoked by Validate after the cursor has
+	// been decoded. Use it to reject revoked or otherwise unacceptable cursors.
+	Validator CursorValidator `json:"-"`
+
+	// secureKey and secureOpts are set by WithSecureKey.
+	secureKey  []byte
+	secureOpts SecureCursorOptions
 }
 
+// CursorValidator checks decoded cursor data, e.g. against a revocation list.
+// A non-nil error is returned unchanged from CursorPaginator.Validate.
+type CursorValidator func(data *CursorData[any]) error
+
 // CursorData holds the data encoded in a cursor.
 // This structure is base64-encoded and can optionally be signed for security.
 // The type parameter T controls the type of Value, enabling type-safe round-trips.
 type CursorData[T any] struct {
-	ID        string    `json:"id,omitempty"`
-	Value     T         `json:"v,omitempty"`
-	Timestamp time.Time `json:"ts,omitzero"`
-	Offset    int       `json:"o,omitempty"`
+	ID        string     `json:"id,omitempty"`
+	Value     T          `json:"v,omitempty"`
+	Timestamp time.Time  `json:"ts,omitzero"`
+	Offset    int        `json:"o,omitempty"`
+	Limit     int        `json:"l,omitempty"`    // limit the Offset is expressed in
+	Seed      int64      `json:"seed,omitempty"` // shuffle seed for randomized orderings
+	Kind      CursorKind `json:"k,omitempty"`    // stamped on encode; see EffectiveKind
+	CursorID  string     `json:"jti,omitempty"`  // unique cursor id for auditing/revocation
+	Issuer    string     `json:"iss,omitempty"`
+}
+
+// CursorKind identifies how a cursor encodes its position, which determines
+// the rules CursorPaginator.Validate enforces for it.
+type CursorKind int
+
+// Cursor kinds.
+const (
+	CursorKindUnknown CursorKind = iota
+	CursorKindOffset
+	CursorKindKeyset
+	CursorKindID
+	CursorKindTimestamp
+)
+
+// String returns the name of the cursor kind.
+func (k CursorKind) String() string {
+	switch k {
+	case CursorKindOffset:
+		return "offset"
+	case CursorKindKeyset:
+		return "keyset"
+	case CursorKindID:
+		return "id"
+	case CursorKindTimestamp:
+		return "timestamp"
+	default:
+		return "unknown"
+	}
+}
+
+// EffectiveKind returns the kind stamped on the cursor, or for cursors
+// issued before kinds were stamped, the kind inferred from the fields set:
+// an offset or limit means offset, a value keyset, a timestamp timestamp and
+// an ID alone id.
+func (d *CursorData[T]) EffectiveKind() CursorKind {
+	if d.Kind != CursorKindUnknown {
+		return d.Kind
+	}
+	switch {
+	case d.Offset != 0 || d.Limit != 0 || d.Seed != 0:
+		return CursorKindOffset
+	case !reflect.ValueOf(&d.Value).Elem().IsZero():
+		return CursorKindKeyset
+	case !d.Timestamp.IsZero():
+		return CursorKindTimestamp
+	case d.ID != "":
+		return CursorKindID
+	default:
+		return CursorKindUnknown
+	}
 }
 
 // NewCursor creates a new cursor paginator with default values.
@@ -71,12 +145,52 @@ func (c *CursorPaginator) WithForward(forward bool) *CursorPaginator {
 	return clone
 }
 
+// Reversed returns a new cursor paginator with the opposite direction,
+// keeping the cursor and limit, e.g. to build "load newer" and "load older"
+// controls from one state.
+// This method is thread-safe as it returns a new instance.
+func (c *CursorPaginator) Reversed() *CursorPaginator {
+	return c.WithForward(!c.Forward)
+}
+
+// ClampLimit returns a new cursor paginator whose limit does not exceed the
+// number of remaining items, with a minimum of 1.
+// This method is thread-safe as it returns a new instance.
+func (c *CursorPaginator) ClampLimit(remaining int) *CursorPaginator {
+	clone := c.Clone()
+	clone.Limit = max(min(c.Limit, remaining), 1)
+	return clone
+}
+
+// WithValidator returns a new cursor paginator with the specified validator hook.
+// This method is thread-safe as it returns a new instance.
+func (c *CursorPaginator) WithValidator(v CursorValidator) *CursorPaginator {
+	clone := c.Clone()
+	clone.Validator = v
+	return clone
+}
+
+// WithSecureKey returns a new cursor paginator whose Decode and Encode use
+// DecodeSecureCursor and EncodeSecureCursor with the given key and options,
+// so Validate, ToOffsetPaginator and IsBackwardStart work with secure
+// cursors. Plain cursors are then rejected with ErrInvalidCursor.
+// This method is thread-safe as it returns a new instance.
+func (c *CursorPaginator) WithSecureKey(key []byte, opts SecureCursorOptions) *CursorPaginator {
+	clone := c.Clone()
+	clone.secureKey = key
+	clone.secureOpts = opts
+	return clone
+}
+
 // Clone creates a copy of the cursor paginator.
 func (c *CursorPaginator) Clone() *CursorPaginator {
 	return &CursorPaginator{
-		Cursor:  c.Cursor,
-		Limit:   c.Limit,
-		Forward: c.Forward,
+		Cursor:     c.Cursor,
+		Limit:      c.Limit,
+		Forward:    c.Forward,
+		Validator:  c.Validator,
+		secureKey:  c.secureKey,
+		secureOpts: c.secureOpts,
 	}
 }
 
@@ -85,30 +199,183 @@ func (c *CursorPaginator) HasCursor() bool {
 	return c.Cursor != ""
 }
 
-// Decode decodes the cursor into CursorData[any].
+// ShouldCount returns true if no cursor is set, i.e. on the first page. APIs
+// that return the total count only on the first page use it to skip the
+// COUNT query on subsequent requests.
+func (c *CursorPaginator) ShouldCount() bool {
+	return !c.HasCursor()
+}
+
+// IsBackwardStart returns true if c pages backward from the start of the
+// collection, i.e. from an offset cursor at offset 0. There is nothing before
+// that position, so the handler should respond with an empty page and
+// HasMore=false (see NewEmptyCursorPage) rather than clamping and returning
+// forward results. Keyset and ID cursors carry no position, so for those the
+// handler must detect the first item itself and apply the same contract.
+// Returns false for forward paging or a missing or invalid cursor.
+func (c *CursorPaginator) IsBackwardStart() bool {
+	if c.Forward || !c.HasCursor() {
+		return false
+	}
+	data, err := c.Decode()
+	if err != nil {
+		return false
+	}
+	switch data.EffectiveKind() {
+	case CursorKindOffset:
+		return data.Offset <= 0
+	default:
+		return false
+	}
+}
+
+// Decode decodes the cursor into CursorData[any], with DecodeSecureCursor if
+// a key was set by WithSecureKey.
 // Returns nil if no cursor is set, or an error if the cursor is invalid.
 func (c *CursorPaginator) Decode() (*CursorData[any], error) {
 	if c.Cursor == "" {
 		return nil, nil
 	}
+	if c.secureKey != nil {
+		return DecodeSecureCursor[any](c.Cursor, c.secureKey, c.secureOpts)
+	}
 	return DecodeCursor[any](c.Cursor)
 }
 
 // Encode encodes cursor data and returns a base64 cursor string.
-// This is a convenience method that delegates to the package-level EncodeCursor.
+// This is a convenience method that delegates to the package-level
+// EncodeCursor, or EncodeSecureCursor if a key was set by WithSecureKey.
 func (c *CursorPaginator) Encode(data CursorData[any]) (string, error) {
+	if c.secureKey != nil {
+		return EncodeSecureCursor(&data, c.secureKey, c.secureOpts)
+	}
 	return EncodeCursor(&data)
 }
 
+// EstimatedPagesFromTotal calculates the approximate number of pages from a
+// total count reported alongside cursor results (e.g. Connection.TotalCount).
+// Returns 0 if total is unknown (negative) or zero.
+func (c *CursorPaginator) EstimatedPagesFromTotal(total int64) int {
+	return (&Paginator{PageSize: c.Limit}).TotalPages(total)
+}
+
+// ToOffsetPaginator converts the cursor paginator to an equivalent offset
+// paginator for backends that only support offset pagination. The Offset of
+// an offset cursor is used; for an ID cursor, a numeric ID is interpreted as
+// a row number on a best-effort basis. Offsets that are not a multiple of
+// Limit are rounded down to the containing page.
+// Without a cursor, or with an offset-0 cursor, the first page is returned.
+// The paginator is 1-based; use WithOptions to renumber it.
+// Returns ErrInvalidCursor if the cursor is malformed, is a keyset or
+// timestamp cursor, or is an ID cursor with a non-numeric ID.
+func (c *CursorPaginator) ToOffsetPaginator() (*Paginator, error) {
+	data, err := c.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	if data != nil {
+		switch kind := data.EffectiveKind(); {
+		case kind == CursorKindOffset:
+			offset = max(data.Offset, 0)
+		case kind == CursorKindID:
+			row, err := strconv.Atoi(data.ID)
+			if err != nil || row < 0 {
+				return nil, fmt.Errorf("%w: cursor has no offset and a non-numeric id", ErrInvalidCursor)
+			}
+			offset = row
+		default:
+			return nil, fmt.Errorf("%w: %s cursor has no offset", ErrInvalidCursor, kind)
+		}
+	}
+
+	p := New().WithPageSize(c.Limit)
+	return p.WithPage(offset/p.PageSize + p.firstPage()), nil
+}
+
+// LimitChanged reports whether the client changed the limit mid-walk in a way
+// that garbles an offset cursor. If the cursor carries the limit it was issued
+// for (see NewCursorFromOffsetLimit), it is compared with the current Limit.
+// Otherwise, since offsets produced while paging are multiples of the limit
+// in use, an offset that is not a multiple of the current Limit indicates the
+// limit changed. Returns false for a nil cursor or one without an offset.
+func (c *CursorPaginator) LimitChanged(data *CursorData[any]) bool {
+	if data == nil || c.Limit <= 0 {
+		return false
+	}
+	if data.Limit > 0 {
+		return data.Limit != c.Limit
+	}
+	return data.Offset != 0 && data.Offset%c.Limit != 0
+}
+
 // Validate validates the cursor paginator parameters.
+// The cursor is checked according to its kind: an offset cursor stamped with
+// a different limit than Limit is rejected, as its offset would point at the
+// wrong position, while keyset, id and timestamp cursors may change limit
+// freely but must carry their position field. Violations return
+// ErrInvalidCursor.
+// If a Validator is set, it is called with the decoded cursor data.
 func (c *CursorPaginator) Validate() error {
 	if c.Limit < MinPageSize || c.Limit > MaxPageSize {
 		return ErrInvalidPageSize
 	}
 	if c.Cursor != "" {
-		if _, err := c.Decode(); err != nil {
+		data, err := c.Decode()
+		if err != nil {
+			return err
+		}
+		if err := c.validateKind(data); err != nil {
 			return err
 		}
+		if c.Validator != nil {
+			return c.Validator(data)
+		}
+	}
+	return nil
+}
+
+// validateKind enforces the rules of the cursor's kind.
+func (c *CursorPaginator) validateKind(data *CursorData[any]) error {
+	kind := data.EffectiveKind()
+	switch kind {
+	case CursorKindOffset:
+		if data.Limit > 0 && data.Limit != c.Limit {
+			return fmt.Errorf("%w: cursor offset %d was issued for limit %d, got limit %d",
+				ErrInvalidCursor, data.Offset, data.Limit, c.Limit)
+		}
+	case CursorKindKeyset:
+		if data.Value == nil {
+			return fmt.Errorf("%w: %s cursor has no value", ErrInvalidCursor, kind)
+		}
+	case CursorKindTimestamp:
+		if data.Timestamp.IsZero() {
+			return fmt.Errorf("%w: %s cursor has no timestamp", ErrInvalidCursor, kind)
+		}
+	case CursorKindID:
+		if data.ID == "" {
+			return fmt.Errorf("%w: %s cursor has no id", ErrInvalidCursor, kind)
+		}
+	}
+	return nil
+}
+
+// ValidateRelay checks the query values the paginator was parsed from against
+// the Relay connection spec, applying the same rule as CursorFromRelayArgs:
+// forward arguments (first/after) cannot be combined with backward ones
+// (last/before). CursorFromQuery otherwise lets the later argument win
+// silently.
+func (c *CursorPaginator) ValidateRelay(q url.Values) error {
+	return checkRelayConflict(q.Get("first") != "" || q.Get("after") != "",
+		q.Get("last") != "" || q.Get("before") != "")
+}
+
+// checkRelayConflict returns ErrRelayArgsConflict if Relay arguments of both
+// directions were given.
+func checkRelayConflict(forward, backward bool) error {
+	if forward && backward {
+		return fmt.Errorf("%w: cannot combine first/after with last/before", ErrRelayArgsConflict)
 	}
 	return nil
 }
@@ -132,11 +399,24 @@ func CursorFromRequest(r *http.Request) *CursorPaginator {
 	return CursorFromQuery(r.URL.Query())
 }
 
+// CursorFromRequestForm parses cursor pagination from a form-encoded request
+// body, falling back to the query string. See FromRequestForm.
+func CursorFromRequestForm(r *http.Request) (*CursorPaginator, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return CursorFromQuery(r.Form), nil
+}
+
 // CursorFromQuery parses cursor pagination from URL query values.
 // Supports multiple query parameter formats:
 //   - cursor + limit (generic)
 //   - after/before + limit (directional)
 //   - first/last (GraphQL-style)
+//   - cursor + direction (forward/next or backward/prev)
+//
+// The direction parameter only applies to the generic cursor; after/before
+// and first/last take precedence over it. Unknown directions are ignored.
 func CursorFromQuery(q url.Values) *CursorPaginator {
 	c := NewCursor()
 
@@ -145,6 +425,14 @@ func CursorFromQuery(q url.Values) *CursorPaginator {
 		c = c.WithCursor(cursor)
 	}
 
+	// Direction for the generic cursor
+	switch strings.ToLower(q.Get("direction")) {
+	case "forward", "next":
+		c = c.WithForward(true)
+	case "backward", "prev":
+		c = c.WithForward(false)
+	}
+
 	// Support "after" and "before" cursors (more explicit)
 	if after := q.Get("after"); after != "" {
 		c = c.WithCursor(after).WithForward(true)
@@ -175,40 +463,278 @@ func CursorFromQuery(q url.Values) *CursorPaginator {
 	return c
 }
 
+// CursorFromRelayArgs builds a cursor paginator from typed Relay connection
+// arguments, as received by GraphQL resolvers. Nil arguments are absent.
+// first/after paginate forward and last/before backward; without a count the
+// limit defaults to DefaultPageSize.
+// Returns ErrRelayArgsConflict if arguments of both directions are combined,
+// or ErrInvalidPageSize if first or last is not positive.
+func CursorFromRelayArgs(first, last *int, after, before *string) (*CursorPaginator, error) {
+	backward := last != nil || before != nil
+	if err := checkRelayConflict(first != nil || after != nil, backward); err != nil {
+		return nil, err
+	}
+
+	c := NewCursor().WithForward(!backward)
+
+	count, cursor := first, after
+	if backward {
+		count, cursor = last, before
+	}
+	if count != nil {
+		if *count < MinPageSize {
+			return nil, fmt.Errorf("%w: got %d", ErrInvalidPageSize, *count)
+		}
+		c = c.WithLimit(*count)
+	}
+	if cursor != nil {
+		c = c.WithCursor(*cursor)
+	}
+	return c, nil
+}
+
+// SeekAround returns two cursor paginators anchored at cursor, for showing an
+// item in context (e.g. a permalink): prev pages backward from the anchor
+// with limit before, and next pages forward with limit after. A side whose
+// count is 0 is returned as nil. Limits above MaxPageSize are clamped.
+// Returns ErrInvalidCursor if the cursor is empty or malformed, or
+// ErrInvalidPageSize if a count is negative.
+func SeekAround(cursor string, before, after int) (prev, next *CursorPaginator, err error) {
+	if cursor == "" {
+		return nil, nil, fmt.Errorf("%w: missing anchor cursor", ErrInvalidCursor)
+	}
+	if _, err := DecodeCursorRaw(cursor); err != nil {
+		return nil, nil, err
+	}
+	if before < 0 || after < 0 {
+		return nil, nil, fmt.Errorf("%w: got before=%d after=%d", ErrInvalidPageSize, before, after)
+	}
+
+	anchor := NewCursor().WithCursor(cursor)
+	if before > 0 {
+		prev = anchor.WithLimit(before).WithForward(false)
+	}
+	if after > 0 {
+		next = anchor.WithLimit(after)
+	}
+	return prev, next, nil
+}
+
 // EncodeCursor encodes cursor data to a base64 string.
+// The payload is serialized with the codec selected by CursorFormat (JSON by
+// default). The JSON encoding is canonical: fields are emitted in struct order
+// and map keys (including those nested in Value) are sorted, so the same
+// CursorData always encodes to the identical string.
+// The Timestamp is normalized to UTC with its monotonic clock reading
+// stripped, so cursors compare by UTC instant regardless of the input zone.
 // Returns an empty string and nil error if data is nil.
-// Returns an error if the data cannot be marshaled to JSON.
+// Returns an error if the data cannot be marshaled.
 func EncodeCursor[T any](data *CursorData[T]) (string, error) {
+	return EncodeCursorWithPrecision(data, 0)
+}
+
+// EncodeCursorWithPrecision encodes cursor data like EncodeCursor, truncating
+// the Timestamp to the given precision first. Use this to match the precision
+// of the stored column (e.g. time.Microsecond for PostgreSQL timestamptz) so
+// seek comparisons against the database behave as expected.
+// A precision <= 0 keeps the full nanosecond precision.
+func EncodeCursorWithPrecision[T any](data *CursorData[T], precision time.Duration) (string, error) {
 	if data == nil {
 		return "", nil
 	}
-	b, err := json.Marshal(data)
+	normalized := normalizeCursor(data, precision)
+	b, err := marshalCursor(&normalized)
 	if err != nil {
 		return "", err
 	}
-	return base64.URLEncoding.EncodeToString(b), nil
+	return checkCursorSize(EncodeCursorRaw(b))
+}
+
+// EncodeCursors encodes a batch of cursors, reusing a single buffer to reduce
+// allocations when a cursor is emitted with every message (e.g. websocket
+// streaming). The output matches EncodeCursor for each input; nil entries
+// produce an empty string. Non-JSON codecs are encoded one at a time.
+func EncodeCursors[T any](datas []*CursorData[T]) ([]string, error) {
+	result := make([]string, len(datas))
+
+	if CursorFormat != CursorFormatJSON {
+		for i, data := range datas {
+			cursor, err := EncodeCursor(data)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = cursor
+		}
+		return result, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	var dst []byte
+
+	for i, data := range datas {
+		if data == nil {
+			continue
+		}
+		buf.Reset()
+		normalized := normalizeCursor(data, 0)
+		if err := enc.Encode(&normalized); err != nil {
+			return nil, err
+		}
+		b := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+		dst = base64.URLEncoding.AppendEncode(dst[:0], b)
+		if MaxCursorBytes > 0 && len(dst) > MaxCursorBytes {
+			return nil, fmt.Errorf("%w: %d bytes, max %d", ErrCursorTooLarge, len(dst), MaxCursorBytes)
+		}
+		result[i] = string(dst)
+	}
+
+	return result, nil
+}
+
+// MaxCursorBytes limits the length of encoded cursors so servers never emit
+// cursors clients cannot send back in a URL. Encoders return
+// ErrCursorTooLarge when a cursor exceeds it. Zero means unlimited.
+// It should only be set during program initialization.
+var MaxCursorBytes = 0
+
+// checkCursorSize returns the cursor, or ErrCursorTooLarge if it exceeds MaxCursorBytes.
+func checkCursorSize(cursor string) (string, error) {
+	if MaxCursorBytes > 0 && len(cursor) > MaxCursorBytes {
+		return "", fmt.Errorf("%w: %d bytes, max %d", ErrCursorTooLarge, len(cursor), MaxCursorBytes)
+	}
+	return cursor, nil
+}
+
+// EncodeCursorRaw wraps an arbitrary payload as a cursor string, for callers
+// that store their own schema instead of CursorData.
+func EncodeCursorRaw(payload []byte) string {
+	return base64.URLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursorRaw decodes a cursor string to its raw payload bytes without
+// assuming the CursorData shape. Returns nil if the cursor is empty, or
+// ErrInvalidCursor if it is malformed.
+func DecodeCursorRaw(cursor string) ([]byte, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	b, err := decodeCursorBytes(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return b, nil
+}
+
+// normalizeCursor returns a copy of data prepared for encoding: the Timestamp
+// is normalized and truncated to precision (if > 0) and the Kind is stamped.
+func normalizeCursor[T any](data *CursorData[T], precision time.Duration) CursorData[T] {
+	normalized := *data
+	normalized.Timestamp = normalizeTimestamp(data.Timestamp)
+	if precision > 0 {
+		normalized.Timestamp = normalized.Timestamp.Truncate(precision)
+	}
+	normalized.Kind = normalized.EffectiveKind()
+	return normalized
+}
+
+// normalizeTimestamp converts ts to UTC and strips its monotonic clock reading.
+// The zero time is returned unchanged so it is still omitted when encoding.
+func normalizeTimestamp(ts time.Time) time.Time {
+	if ts.IsZero() {
+		return ts
+	}
+	return ts.UTC().Round(0)
 }
 
 // DecodeCursor decodes a base64 cursor string to cursor data.
+// The payload is decoded with the registered codec matching its format marker.
+// Both URL-safe and standard base64, padded or not, are accepted so cursors
+// survive intermediaries that re-encode them.
 // Returns an error if the cursor is malformed.
 func DecodeCursor[T any](cursor string) (*CursorData[T], error) {
 	if cursor == "" {
 		return nil, nil
 	}
 
-	b, err := base64.URLEncoding.DecodeString(cursor)
+	b, err := DecodeCursorRaw(cursor)
 	if err != nil {
-		return nil, ErrInvalidCursor
+		return nil, err
 	}
 
 	var data CursorData[T]
-	if err := json.Unmarshal(b, &data); err != nil {
+	if err := unmarshalCursor(b, &data); err != nil {
 		return nil, ErrInvalidCursor
 	}
 
 	return &data, nil
 }
 
+// DecodeCursorStrict decodes a cursor like DecodeCursor, but rejects JSON
+// cursors carrying fields outside the CursorData schema or trailing data with
+// ErrInvalidCursor, to catch forged or corrupted cursors early. Cursors of
+// other registered formats are decoded by their codec as usual.
+func DecodeCursorStrict[T any](cursor string) (*CursorData[T], error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	b, err := DecodeCursorRaw(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 || b[0] != CursorFormatJSON {
+		return DecodeCursor[T](cursor)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	var data CursorData[T]
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%w: trailing data", ErrInvalidCursor)
+	}
+
+	return &data, nil
+}
+
+// SameCursor reports whether two cursors refer to the same position.
+// Only position-defining fields (ID, Value, Timestamp, Offset, Seed) are compared;
+// volatile fields such as CursorID and Issuer are ignored. Two empty cursors
+// are considered the same. Returns an error if either cursor is malformed.
+func SameCursor(a, b string) (bool, error) {
+	da, err := DecodeCursor[any](a)
+	if err != nil {
+		return false, err
+	}
+	db, err := DecodeCursor[any](b)
+	if err != nil {
+		return false, err
+	}
+	if da == nil || db == nil {
+		return da == db, nil
+	}
+	return da.ID == db.ID &&
+		da.Offset == db.Offset &&
+		da.Seed == db.Seed &&
+		da.Timestamp.Equal(db.Timestamp) &&
+		reflect.DeepEqual(da.Value, db.Value), nil
+}
+
+// cursorAlphabet maps the standard base64 alphabet onto the URL-safe one.
+var cursorAlphabet = strings.NewReplacer("+", "-", "/", "_")
+
+// decodeCursorBytes decodes a base64 cursor regardless of alphabet or padding.
+// The cursor is normalized to unpadded URL-safe base64 before decoding, so
+// padded, unpadded, and mixed-alphabet inputs are all accepted.
+func decodeCursorBytes(cursor string) ([]byte, error) {
+	normalized := strings.TrimRight(cursorAlphabet.Replace(cursor), "=")
+	return base64.RawURLEncoding.DecodeString(normalized)
+}
+
 // NewCursorFromID creates a cursor from an ID.
 func NewCursorFromID(id string) (string, error) {
 	return EncodeCursor(&CursorData[any]{ID: id})
@@ -216,18 +742,71 @@ func NewCursorFromID(id string) (string, error) {
 
 // NewCursorFromValue creates a cursor from a typed value.
 // Note: The value should be JSON-serializable.
+// Decode with the matching concrete type (DecodeCursor[T]) to round-trip the
+// value exactly; decoding into CursorData[any] yields float64 for any number,
+// which loses precision for integers beyond 2^53.
 func NewCursorFromValue[T any](value T) (string, error) {
 	return EncodeCursor(&CursorData[T]{Value: value})
 }
 
+// NewCursorFromFloat64 creates a cursor from a float sort value and an ID
+// tie-breaker, e.g. for feeds ordered by score. Floats are encoded in their
+// shortest exact form, so DecodeCursor[float64] restores the identical value.
+// Returns an error for NaN and infinities, which JSON cannot represent.
+func NewCursorFromFloat64(v float64, id string) (string, error) {
+	return EncodeCursor(&CursorData[float64]{Value: v, ID: id})
+}
+
 // NewCursorFromTimestamp creates a cursor from a timestamp and ID.
 // This is useful for time-based pagination with tie-breaking.
+// The timestamp is stored as a UTC instant, so equal instants in different
+// zones produce identical cursors.
 func NewCursorFromTimestamp(ts time.Time, id string) (string, error) {
 	return EncodeCursor(&CursorData[any]{Timestamp: ts, ID: id})
 }
 
+// NewCursorFull creates a cursor stamped with an issuer and a unique cursor id
+// (jti), for deployments that audit or revoke issued cursors.
+// A random CursorID is generated unless data already carries one.
+func NewCursorFull[T any](data CursorData[T], issuer string) (string, error) {
+	if data.CursorID == "" {
+		id, err := newCursorID()
+		if err != nil {
+			return "", err
+		}
+		data.CursorID = id
+	}
+	data.Issuer = issuer
+	return EncodeCursor(&data)
+}
+
+// newCursorID returns a random 128-bit hex-encoded cursor id.
+func newCursorID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // NewCursorFromOffset creates a cursor from an offset.
 // This allows using cursor-style APIs with offset-based backends.
 func NewCursorFromOffset(offset int) (string, error) {
-	return EncodeCursor(&CursorData[any]{Offset: offset})
+	return EncodeCursor(&CursorData[any]{Offset: offset, Kind: CursorKindOffset})
+}
+
+// NewCursorWithSeed creates an offset cursor carrying a shuffle seed, for
+// paginating a randomized order that must stay stable across pages (e.g. a
+// "discover" feed per user session). The backend must derive the order from
+// the seed deterministically, e.g. ORDER BY md5(id || seed), so every page
+// sees the same permutation.
+func NewCursorWithSeed(seed int64, offset int) (string, error) {
+	return EncodeCursor(&CursorData[any]{Offset: offset, Seed: seed, Kind: CursorKindOffset})
+}
+
+// NewCursorFromOffsetLimit creates an offset cursor that also records the
+// limit the offset is expressed in, so Validate and LimitChanged can detect
+// clients changing the limit mid-walk.
+func NewCursorFromOffsetLimit(offset, limit int) (string, error) {
+	return EncodeCursor(&CursorData[any]{Offset: offset, Limit: limit, Kind: CursorKindOffset})
 }
@@ -1,8 +1,13 @@
 package paginate
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 )
@@ -59,6 +64,48 @@ func TestCursorWithForward(t *testing.T) {
 	}
 }
 
+func TestCursorReversed(t *testing.T) {
+	c := NewCursorWithLimit(15).WithCursor("abc")
+	r := c.Reversed()
+
+	if r.Forward || r.Cursor != "abc" || r.Limit != 15 {
+		t.Errorf("Unexpected reversed paginator: %+v", r)
+	}
+	if !c.Forward {
+		t.Error("Original paginator should not be modified")
+	}
+	if !r.Reversed().Forward {
+		t.Error("Reversing twice should restore the direction")
+	}
+}
+
+func TestCursorClampLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		remaining int
+		expected  int
+	}{
+		{"Fewer remaining", 20, 7, 7},
+		{"More remaining", 20, 50, 20},
+		{"None remaining", 20, 0, 1},
+		{"Negative remaining", 20, -3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCursorWithLimit(tt.limit)
+			clamped := c.ClampLimit(tt.remaining)
+			if clamped.Limit != tt.expected {
+				t.Errorf("Expected limit %d, got %d", tt.expected, clamped.Limit)
+			}
+			if c.Limit != tt.limit {
+				t.Error("ClampLimit should not modify the original paginator")
+			}
+		})
+	}
+}
+
 func TestCursorClone(t *testing.T) {
 	c1 := NewCursor().WithCursor("test").WithLimit(50)
 	c2 := c1.Clone()
@@ -155,6 +202,139 @@ func TestEncodeCursor(t *testing.T) {
 	}
 }
 
+func TestEncodeCursorDeterministic(t *testing.T) {
+	build := func() *CursorData[map[string]any] {
+		value := map[string]any{}
+		// Insert keys in a different order on each call
+		for _, k := range []string{"status", "category", "owner", "zone", "alpha"} {
+			value[k] = map[string]int{"b": 2, "a": 1, k: len(k)}
+		}
+		return &CursorData[map[string]any]{ID: "x", Value: value}
+	}
+
+	first, err := EncodeCursor(build())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for range 50 {
+		cursor, err := EncodeCursor(build())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cursor != first {
+			t.Fatalf("Expected stable cursor %s, got %s", first, cursor)
+		}
+	}
+
+	batch, err := EncodeCursors([]*CursorData[map[string]any]{build()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if batch[0] != first {
+		t.Errorf("Expected batch encoding %s, got %s", first, batch[0])
+	}
+
+	raw, err := DecodeCursorRaw(first)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(raw), `{"id":"x","v":{"alpha":`) {
+		t.Errorf("Expected sorted keys in struct order, got %s", raw)
+	}
+}
+
+func TestMaxCursorBytes(t *testing.T) {
+	small := &CursorData[any]{ID: "a"}
+	large := &CursorData[any]{ID: strings.Repeat("x", 200)}
+
+	// Unlimited by default
+	if _, err := EncodeCursor(large); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	MaxCursorBytes = 64
+	defer func() { MaxCursorBytes = 0 }()
+
+	if _, err := EncodeCursor(small); err != nil {
+		t.Errorf("Unexpected error for small cursor: %v", err)
+	}
+	if _, err := EncodeCursor(large); !errors.Is(err, ErrCursorTooLarge) {
+		t.Errorf("Expected ErrCursorTooLarge, got %v", err)
+	}
+	if _, err := EncodeCursors([]*CursorData[any]{small, large}); !errors.Is(err, ErrCursorTooLarge) {
+		t.Errorf("Expected ErrCursorTooLarge from batch, got %v", err)
+	}
+	if _, err := NewKeysetCursor([]string{"id"}, []any{strings.Repeat("x", 200)}); !errors.Is(err, ErrCursorTooLarge) {
+		t.Errorf("Expected ErrCursorTooLarge from keyset cursor, got %v", err)
+	}
+}
+
+func TestEncodeCursorWithPrecision(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		name      string
+		precision time.Duration
+		expected  time.Time
+	}{
+		{"Full precision", 0, ts},
+		{"Microseconds", time.Microsecond, time.Date(2024, 1, 1, 12, 0, 0, 123456000, time.UTC)},
+		{"Milliseconds", time.Millisecond, time.Date(2024, 1, 1, 12, 0, 0, 123000000, time.UTC)},
+		{"Seconds", time.Second, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := EncodeCursorWithPrecision(&CursorData[any]{Timestamp: ts}, tt.precision)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			data, err := DecodeCursor[any](cursor)
+			if err != nil {
+				t.Fatalf("Unexpected decode error: %v", err)
+			}
+			if !data.Timestamp.Equal(tt.expected) {
+				t.Errorf("Expected timestamp %v, got %v", tt.expected, data.Timestamp)
+			}
+		})
+	}
+
+	cursor, err := EncodeCursorWithPrecision[any](nil, time.Microsecond)
+	if err != nil || cursor != "" {
+		t.Errorf("Expected empty cursor and nil error for nil data, got %q, %v", cursor, err)
+	}
+}
+
+func TestEncodeCursors(t *testing.T) {
+	datas := []*CursorData[int]{
+		{ID: "a", Value: 1},
+		nil,
+		{ID: "<b>", Value: 2, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("X", 3600))},
+	}
+
+	cursors, err := EncodeCursors(datas)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cursors) != len(datas) {
+		t.Fatalf("Expected %d cursors, got %d", len(datas), len(cursors))
+	}
+
+	for i, data := range datas {
+		expected, err := EncodeCursor(data)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cursors[i] != expected {
+			t.Errorf("Cursor %d: expected %s, got %s", i, expected, cursors[i])
+		}
+	}
+
+	if _, err := EncodeCursors([]*CursorData[any]{{Value: make(chan int)}}); err == nil {
+		t.Error("Expected error for unserializable value")
+	}
+}
+
 func TestDecodeCursor(t *testing.T) {
 	// Create a cursor and decode it
 	original := &CursorData[any]{
@@ -201,6 +381,163 @@ func TestDecodeCursorInvalid(t *testing.T) {
 	}
 }
 
+func TestDecodeCursorBase64Variants(t *testing.T) {
+	// Value chosen so the encoding contains URL-specific characters
+	original := &CursorData[string]{ID: "??>>", Value: "~~~"}
+	b, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		enc  *base64.Encoding
+	}{
+		{"URL", base64.URLEncoding},
+		{"Standard", base64.StdEncoding},
+		{"Raw URL", base64.RawURLEncoding},
+		{"Raw standard", base64.RawStdEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := DecodeCursor[string](tt.enc.EncodeToString(b))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if data.ID != original.ID || data.Value != original.Value {
+				t.Errorf("Expected %+v, got %+v", original, data)
+			}
+		})
+	}
+
+	encoded, err := EncodeCursor(original)
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	if strings.ContainsAny(encoded, "+/") {
+		t.Errorf("Expected URL-safe encoding, got %s", encoded)
+	}
+}
+
+func TestDecodeCursorPadding(t *testing.T) {
+	// {"id":"ab"} encodes to a padded cursor
+	padded, err := NewCursorFromID("ab")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(padded, "=") {
+		t.Fatalf("Expected padded cursor, got %s", padded)
+	}
+	unpadded := strings.TrimRight(padded, "=")
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"Padded", padded},
+		{"Unpadded", unpadded},
+		{"Partially padded", unpadded + "="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := DecodeCursor[any](tt.cursor)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if data.ID != "ab" {
+				t.Errorf("Expected ID 'ab', got '%s'", data.ID)
+			}
+		})
+	}
+
+	b, err := decodeCursorBytes("dGVzdA")
+	if err != nil || string(b) != "test" {
+		t.Errorf("Expected 'test', got %q, %v", b, err)
+	}
+	b, err = decodeCursorBytes("dGVzdA==")
+	if err != nil || string(b) != "test" {
+		t.Errorf("Expected 'test', got %q, %v", b, err)
+	}
+}
+
+func TestDecodeCursorMixedAlphabet(t *testing.T) {
+	// 0xfb 0xff 0xbf encodes to "+/+/" (standard) or "-_-_" (URL-safe)
+	for _, cursor := range []string{"+/+/", "-_-_", "-/+_"} {
+		b, err := decodeCursorBytes(cursor)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", cursor, err)
+		}
+		if string(b) != "\xfb\xff\xbf" {
+			t.Errorf("Unexpected bytes for %s: %x", cursor, b)
+		}
+	}
+}
+
+func TestCursorRaw(t *testing.T) {
+	payload := []byte(`{"sort":["name","id"],"after":["bob",42]}`)
+
+	cursor := EncodeCursorRaw(payload)
+	if strings.ContainsAny(cursor, "+/") {
+		t.Errorf("Expected URL-safe encoding, got %s", cursor)
+	}
+
+	decoded, err := DecodeCursorRaw(cursor)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(decoded) != string(payload) {
+		t.Errorf("Expected %s, got %s", payload, decoded)
+	}
+
+	if _, err := DecodeCursorRaw("not-valid-base64!!!"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+	if b, err := DecodeCursorRaw(""); b != nil || err != nil {
+		t.Errorf("Expected nil, nil for empty cursor, got %v, %v", b, err)
+	}
+}
+
+func TestDecodeCursorStrict(t *testing.T) {
+	valid, _ := EncodeCursor(&CursorData[string]{ID: "42", Value: "x", Offset: 3})
+
+	data, err := DecodeCursorStrict[string](valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data.ID != "42" || data.Value != "x" || data.Offset != 3 {
+		t.Errorf("Unexpected data: %+v", data)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{"Unknown field", `{"id":"42","admin":true}`},
+		{"Trailing data", `{"id":"42"}{"id":"43"}`},
+		{"Wrong type", `{"id":42}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := EncodeCursorRaw([]byte(tt.payload))
+			if _, err := DecodeCursorStrict[any](cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+
+	lenient := EncodeCursorRaw([]byte(`{"id":"42","admin":true}`))
+	if _, err := DecodeCursor[any](lenient); err != nil {
+		t.Errorf("DecodeCursor should ignore unknown fields, got %v", err)
+	}
+
+	if data, err := DecodeCursorStrict[any](""); data != nil || err != nil {
+		t.Errorf("Expected nil, nil for empty cursor, got %v, %v", data, err)
+	}
+}
+
 func TestDecodeCursorEmpty(t *testing.T) {
 	data, err := DecodeCursor[any]("")
 	if err != nil {
@@ -243,6 +580,364 @@ func TestCursorValidate(t *testing.T) {
 	}
 }
 
+func TestCursorEstimatedPagesFromTotal(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		total    int64
+		expected int
+	}{
+		{"Exact division", 10, 100, 10},
+		{"With remainder", 10, 101, 11},
+		{"Zero total", 10, 0, 0},
+		{"Unknown total", 10, -1, 0},
+		{"Less than limit", 20, 5, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCursorWithLimit(tt.limit)
+			if pages := c.EstimatedPagesFromTotal(tt.total); pages != tt.expected {
+				t.Errorf("Expected %d pages, got %d", tt.expected, pages)
+			}
+		})
+	}
+}
+
+func TestCursorValidateWithValidator(t *testing.T) {
+	revoked, err := NewCursorFromID("revoked")
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	allowed, err := NewCursorFromID("allowed")
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+
+	errRevoked := errors.New("cursor revoked")
+	validator := func(data *CursorData[any]) error {
+		if data.ID == "revoked" {
+			return errRevoked
+		}
+		return nil
+	}
+
+	c := NewCursor().WithValidator(validator)
+
+	if err := c.WithCursor(revoked).Validate(); !errors.Is(err, errRevoked) {
+		t.Errorf("Expected errRevoked, got %v", err)
+	}
+	if err := c.WithCursor(allowed).Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Validator should not run without a cursor, got %v", err)
+	}
+	if err := c.WithCursor("invalid-cursor").Validate(); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestCursorValidateRelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantError bool
+	}{
+		{"First only", "first=10&after=abc", false},
+		{"Last only", "last=10&before=abc", false},
+		{"No args", "", false},
+		{"First and last", "first=10&last=10", true},
+		{"After and before", "after=abc&before=def", true},
+		{"First and before", "first=10&before=abc", true},
+		{"Last and after", "last=10&after=abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			err := CursorFromQuery(q).ValidateRelay(q)
+			if (err != nil) != tt.wantError {
+				t.Errorf("Expected error=%v, got error=%v", tt.wantError, err)
+			}
+			if tt.wantError && !errors.Is(err, ErrRelayArgsConflict) {
+				t.Errorf("Expected ErrRelayArgsConflict, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCursorFromRelayArgs(t *testing.T) {
+	ten, zero := 10, 0
+	abc := "abc"
+
+	tests := []struct {
+		name          string
+		first, last   *int
+		after, before *string
+		limit         int
+		forward       bool
+		cursor        string
+		wantError     error
+	}{
+		{"No args", nil, nil, nil, nil, DefaultPageSize, true, "", nil},
+		{"First", &ten, nil, nil, nil, 10, true, "", nil},
+		{"First and after", &ten, nil, &abc, nil, 10, true, "abc", nil},
+		{"Last and before", nil, &ten, nil, &abc, 10, false, "abc", nil},
+		{"Before only", nil, nil, nil, &abc, DefaultPageSize, false, "abc", nil},
+		{"First and last", &ten, &ten, nil, nil, 0, false, "", ErrRelayArgsConflict},
+		{"After and before", nil, nil, &abc, &abc, 0, false, "", ErrRelayArgsConflict},
+		{"First and before", &ten, nil, nil, &abc, 0, false, "", ErrRelayArgsConflict},
+		{"Zero first", &zero, nil, nil, nil, 0, false, "", ErrInvalidPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := CursorFromRelayArgs(tt.first, tt.last, tt.after, tt.before)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("Expected %v, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if c.Limit != tt.limit || c.Forward != tt.forward || c.Cursor != tt.cursor {
+				t.Errorf("Expected limit=%d forward=%v cursor=%q, got %+v",
+					tt.limit, tt.forward, tt.cursor, c)
+			}
+		})
+	}
+}
+
+func TestCursorShouldCount(t *testing.T) {
+	if !NewCursor().ShouldCount() {
+		t.Error("Expected first page to be counted")
+	}
+	if NewCursor().WithCursor("abc").ShouldCount() {
+		t.Error("Expected later pages not to be counted")
+	}
+}
+
+func TestCursorIsBackwardStart(t *testing.T) {
+	start, _ := NewCursorFromOffset(0)
+	middle, _ := NewCursorFromOffset(20)
+	id, _ := NewCursorFromID("1")
+	emptyValue, _ := NewCursorFromValue("")
+
+	tests := []struct {
+		name     string
+		c        *CursorPaginator
+		expected bool
+	}{
+		{"Backward from offset 0", NewCursor().WithCursor(start).WithForward(false), true},
+		{"Forward from offset 0", NewCursor().WithCursor(start), false},
+		{"Backward from offset 20", NewCursor().WithCursor(middle).WithForward(false), false},
+		{"Backward from ID cursor", NewCursor().WithCursor(id).WithForward(false), false},
+		{"Backward from empty-valued cursor", NewCursor().WithCursor(emptyValue).WithForward(false), false},
+		{"Backward without cursor", NewCursor().WithForward(false), false},
+		{"Backward from invalid cursor", NewCursor().WithCursor("!!!").WithForward(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsBackwardStart(); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCursorKind(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	offset, _ := NewCursorFromOffset(40)
+	zero, _ := NewCursorFromOffset(0)
+	id, _ := NewCursorFromID("42")
+	stamp, _ := NewCursorFromTimestamp(ts, "42")
+	value, _ := NewCursorFromFloat64(1.5, "42")
+
+	tests := []struct {
+		name     string
+		cursor   string
+		expected CursorKind
+	}{
+		{"Offset", offset, CursorKindOffset},
+		{"Offset 0", zero, CursorKindOffset},
+		{"ID", id, CursorKindID},
+		{"Timestamp", stamp, CursorKindTimestamp},
+		{"Keyset", value, CursorKindKeyset},
+		{"Legacy offset", EncodeCursorRaw([]byte(`{"o":40}`)), CursorKindOffset},
+		{"Legacy id", EncodeCursorRaw([]byte(`{"id":"42"}`)), CursorKindID},
+		{"Empty", EncodeCursorRaw([]byte(`{}`)), CursorKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := DecodeCursor[any](tt.cursor)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if kind := data.EffectiveKind(); kind != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, kind)
+			}
+		})
+	}
+}
+
+func TestCursorValidateKind(t *testing.T) {
+	offset, _ := NewCursorFromOffsetLimit(40, 20)
+	id, _ := NewCursorFromID("42")
+
+	tests := []struct {
+		name      string
+		cursor    string
+		limit     int
+		wantError bool
+	}{
+		{"Offset same limit", offset, 20, false},
+		{"Offset changed limit", offset, 10, true},
+		{"ID changed limit", id, 50, false},
+		{"Keyset without value", EncodeCursorRaw([]byte(`{"id":"42","k":2}`)), 20, true},
+		{"Timestamp without timestamp", EncodeCursorRaw([]byte(`{"id":"42","k":4}`)), 20, true},
+		{"ID without id", EncodeCursorRaw([]byte(`{"k":3}`)), 20, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewCursorWithLimit(tt.limit).WithCursor(tt.cursor).Validate()
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Errorf("Expected ErrInvalidCursor, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	if CursorKindTimestamp.String() != "timestamp" || CursorKind(99).String() != "unknown" {
+		t.Error("Unexpected kind names")
+	}
+}
+
+func TestCursorLimitChanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *CursorData[any]
+		limit    int
+		expected bool
+	}{
+		{"Nil cursor", nil, 10, false},
+		{"No offset", &CursorData[any]{ID: "42"}, 10, false},
+		{"Same limit", &CursorData[any]{Offset: 40}, 10, false},
+		{"Compatible limit", &CursorData[any]{Offset: 40}, 20, false},
+		{"Changed limit", &CursorData[any]{Offset: 40}, 25, true},
+		{"Stamped same limit", &CursorData[any]{Offset: 40, Limit: 10}, 10, false},
+		{"Stamped compatible offset", &CursorData[any]{Offset: 40, Limit: 10}, 20, true},
+		{"Stamped first page", &CursorData[any]{Limit: 10}, 20, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCursorWithLimit(tt.limit).LimitChanged(tt.data); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSeekAround(t *testing.T) {
+	anchor, _ := NewCursorFromID("42")
+
+	prev, next, err := SeekAround(anchor, 5, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if prev.Cursor != anchor || prev.Limit != 5 || prev.Forward {
+		t.Errorf("Unexpected prev paginator: %+v", prev)
+	}
+	if next.Cursor != anchor || next.Limit != 10 || !next.Forward {
+		t.Errorf("Unexpected next paginator: %+v", next)
+	}
+
+	prev, next, err = SeekAround(anchor, 0, 10)
+	if err != nil || prev != nil || next == nil {
+		t.Errorf("Expected only next paginator, got %v, %v, %v", prev, next, err)
+	}
+
+	tests := []struct {
+		name          string
+		cursor        string
+		before, after int
+		wantError     error
+	}{
+		{"Empty cursor", "", 5, 5, ErrInvalidCursor},
+		{"Malformed cursor", "!!!", 5, 5, ErrInvalidCursor},
+		{"Negative count", anchor, -1, 5, ErrInvalidPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := SeekAround(tt.cursor, tt.before, tt.after); !errors.Is(err, tt.wantError) {
+				t.Errorf("Expected %v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestCursorToOffsetPaginator(t *testing.T) {
+	offsetCursor, _ := NewCursorFromOffset(40)
+	zeroCursor, _ := NewCursorFromOffset(0)
+	zeroLimitCursor, _ := NewCursorFromOffsetLimit(0, 20)
+	idCursor, _ := NewCursorFromID("60")
+	badIDCursor, _ := NewCursorFromID("user_1")
+	emptyCursor, _ := NewCursorFromValue("x")
+	timestampCursor, _ := NewCursorFromTimestamp(time.Now(), "42")
+	keysetCursor, _ := EncodeCursor(&CursorData[any]{Value: "x", ID: "42"})
+
+	tests := []struct {
+		name         string
+		cursor       string
+		expectedPage int
+		wantError    bool
+	}{
+		{"No cursor", "", 1, false},
+		{"Offset", offsetCursor, 3, false},
+		{"Offset 0", zeroCursor, 1, false},
+		{"Offset 0 with limit", zeroLimitCursor, 1, false},
+		{"Numeric ID", idCursor, 4, false},
+		{"Non-numeric ID", badIDCursor, 0, true},
+		{"No position", emptyCursor, 0, true},
+		{"Timestamp with numeric ID", timestampCursor, 0, true},
+		{"Keyset with numeric ID", keysetCursor, 0, true},
+		{"Malformed", "invalid!!!", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewCursorWithLimit(20).WithCursor(tt.cursor).ToOffsetPaginator()
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Errorf("Expected ErrInvalidCursor, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if p.Page != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, p.Page)
+			}
+			if p.PageSize != 20 {
+				t.Errorf("Expected page size 20, got %d", p.PageSize)
+			}
+		})
+	}
+}
+
 func TestCursorFromRequest(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -257,6 +952,11 @@ func TestCursorFromRequest(t *testing.T) {
 		{"Before cursor", "http://example.com?before=def&limit=20", "def", 20, false},
 		{"GraphQL first", "http://example.com?first=50", "", 50, true},
 		{"GraphQL last", "http://example.com?last=40", "", 40, false},
+		{"Direction backward", "http://example.com?cursor=abc&direction=backward", "abc", DefaultPageSize, false},
+		{"Direction prev", "http://example.com?cursor=abc&direction=PREV", "abc", DefaultPageSize, false},
+		{"Direction next", "http://example.com?cursor=abc&direction=next", "abc", DefaultPageSize, true},
+		{"Unknown direction", "http://example.com?cursor=abc&direction=sideways", "abc", DefaultPageSize, true},
+		{"After overrides direction", "http://example.com?after=xyz&direction=backward", "xyz", DefaultPageSize, true},
 	}
 
 	for _, tt := range tests {
@@ -277,6 +977,22 @@ func TestCursorFromRequest(t *testing.T) {
 	}
 }
 
+func TestCursorFromRequestForm(t *testing.T) {
+	req, _ := http.NewRequest("POST", "http://example.com/search?limit=15", strings.NewReader("after=abc"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	c, err := CursorFromRequestForm(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Cursor != "abc" || !c.Forward {
+		t.Errorf("Expected forward cursor 'abc', got %+v", c)
+	}
+	if c.Limit != 15 {
+		t.Errorf("Expected limit 15 from query, got %d", c.Limit)
+	}
+}
+
 func TestNewCursorFromID(t *testing.T) {
 	cursor, err := NewCursorFromID("user_123")
 	if err != nil {
@@ -321,6 +1037,151 @@ func TestNewCursorFromTimestamp(t *testing.T) {
 	}
 }
 
+func TestNewCursorFromTimestampNormalizesZone(t *testing.T) {
+	utc := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	local := utc.In(zone)
+
+	utcCursor, err := NewCursorFromTimestamp(utc, "item_456")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	localCursor, err := NewCursorFromTimestamp(local, "item_456")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if utcCursor != localCursor {
+		t.Errorf("Expected identical cursors, got %q and %q", utcCursor, localCursor)
+	}
+
+	data, err := DecodeCursor[any](localCursor)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data.Timestamp.Location() != time.UTC {
+		t.Errorf("Expected UTC location, got %v", data.Timestamp.Location())
+	}
+	if !data.Timestamp.Equal(utc) {
+		t.Errorf("Expected timestamp %v, got %v", utc, data.Timestamp)
+	}
+}
+
+func TestEncodeCursorStripsMonotonic(t *testing.T) {
+	now := time.Now()
+	withMono, err := EncodeCursor(&CursorData[any]{Timestamp: now})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	withoutMono, err := EncodeCursor(&CursorData[any]{Timestamp: now.Round(0).In(time.Local)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if withMono != withoutMono {
+		t.Errorf("Expected identical cursors, got %q and %q", withMono, withoutMono)
+	}
+}
+
+func TestNewCursorFull(t *testing.T) {
+	cursor1, err := NewCursorFull(CursorData[int]{ID: "item_1", Value: 7}, "api")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cursor2, err := NewCursorFull(CursorData[int]{ID: "item_1", Value: 7}, "api")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data1, err := DecodeCursor[int](cursor1)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	data2, err := DecodeCursor[int](cursor2)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+
+	if data1.CursorID == "" {
+		t.Error("Expected a generated cursor id")
+	}
+	if data1.CursorID == data2.CursorID {
+		t.Error("Expected unique cursor ids")
+	}
+	if data1.Issuer != "api" {
+		t.Errorf("Expected issuer 'api', got '%s'", data1.Issuer)
+	}
+	if data1.ID != "item_1" || data1.Value != 7 {
+		t.Errorf("Unexpected payload: %+v", data1)
+	}
+
+	// An explicit cursor id is kept
+	cursor, err := NewCursorFull(CursorData[any]{CursorID: "fixed"}, "api")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := DecodeCursor[any](cursor)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	if data.CursorID != "fixed" {
+		t.Errorf("Expected cursor id 'fixed', got '%s'", data.CursorID)
+	}
+
+	// Cursors without jti/iss only carry their position and kind
+	plain, err := NewCursorFromID("item_1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plain != "eyJpZCI6Iml0ZW1fMSIsImsiOjN9" {
+		t.Errorf("Expected encoding with the id position and stamped kind, got %s", plain)
+	}
+}
+
+func TestSameCursor(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	base, err := EncodeCursor(&CursorData[any]{ID: "a", Timestamp: ts, Value: 7})
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	withJTI, err := NewCursorFull(CursorData[any]{ID: "a", Timestamp: ts.In(time.FixedZone("X", 3600)), Value: 7}, "api")
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	other, err := EncodeCursor(&CursorData[any]{ID: "a", Timestamp: ts, Value: 8})
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"Identical", base, base, true},
+		{"Different jti and zone", base, withJTI, true},
+		{"Different value", base, other, false},
+		{"Both empty", "", "", true},
+		{"One empty", base, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			same, err := SameCursor(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if same != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, same)
+			}
+		})
+	}
+
+	if _, err := SameCursor(base, "invalid!!!"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
 func TestNewCursorFromOffset(t *testing.T) {
 	cursor, err := NewCursorFromOffset(100)
 	if err != nil {
@@ -339,6 +1200,61 @@ func TestNewCursorFromOffset(t *testing.T) {
 	}
 }
 
+func TestNewCursorWithSeed(t *testing.T) {
+	cursor, err := NewCursorWithSeed(987654321, 40)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := DecodeCursor[any](cursor)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data.Seed != 987654321 || data.Offset != 40 {
+		t.Errorf("Expected seed 987654321 and offset 40, got %+v", data)
+	}
+	if data.EffectiveKind() != CursorKindOffset {
+		t.Errorf("Expected offset kind, got %v", data.EffectiveKind())
+	}
+
+	other, _ := NewCursorWithSeed(123, 40)
+	if same, _ := SameCursor(cursor, other); same {
+		t.Error("Cursors with different seeds should not be the same")
+	}
+
+	first, _ := NewCursorWithSeed(0, 0)
+	data, _ = DecodeCursor[any](first)
+	if data.Seed != 0 || data.Offset != 0 || data.EffectiveKind() != CursorKindOffset {
+		t.Errorf("Unexpected zero-seed cursor: %+v", data)
+	}
+}
+
+func TestNewCursorFromOffsetLimit(t *testing.T) {
+	cursor, err := NewCursorFromOffsetLimit(40, 20)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := DecodeCursor[any](cursor)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data.Offset != 40 || data.Limit != 20 {
+		t.Errorf("Expected offset 40 and limit 20, got %+v", data)
+	}
+
+	if err := NewCursorWithLimit(20).WithCursor(cursor).Validate(); err != nil {
+		t.Errorf("Expected matching limit to validate, got %v", err)
+	}
+	err = NewCursorWithLimit(50).WithCursor(cursor).Validate()
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for changed limit, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "limit 20") {
+		t.Errorf("Expected descriptive error, got %v", err)
+	}
+}
+
 func TestCursorQueryParams(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -417,6 +1333,39 @@ func TestNewCursorFromValue(t *testing.T) {
 	}
 }
 
+func TestNewCursorFromFloat64(t *testing.T) {
+	values := []float64{0, 0.1, -2.5, 1.0 / 3, math.Pi, math.MaxFloat64, math.SmallestNonzeroFloat64, 1 << 53}
+
+	for _, v := range values {
+		cursor, err := NewCursorFromFloat64(v, "id-1")
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", v, err)
+		}
+
+		data, err := DecodeCursor[float64](cursor)
+		if err != nil {
+			t.Fatalf("%v: unexpected decode error: %v", v, err)
+		}
+		if data.Value != v || data.ID != "id-1" {
+			t.Errorf("Expected (%v, id-1), got (%v, %s)", v, data.Value, data.ID)
+		}
+
+		anyData, err := DecodeCursor[any](cursor)
+		if err != nil {
+			t.Fatalf("%v: unexpected decode error: %v", v, err)
+		}
+		if v != 0 && anyData.Value != v {
+			t.Errorf("Expected %v when decoded as any, got %v", v, anyData.Value)
+		}
+	}
+
+	for _, v := range []float64{math.NaN(), math.Inf(1)} {
+		if _, err := NewCursorFromFloat64(v, "id"); err == nil {
+			t.Errorf("Expected error for %v", v)
+		}
+	}
+}
+
 func TestCursorRoundTrip(t *testing.T) {
 	// Test that encoding and decoding preserves data
 	original := &CursorData[any]{
@@ -459,6 +1408,21 @@ func BenchmarkEncodeCursor(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodeCursors(b *testing.B) {
+	datas := make([]*CursorData[any], 100)
+	for i := range datas {
+		datas[i] = &CursorData[any]{
+			ID:        "user_123",
+			Timestamp: time.Now(),
+			Offset:    i,
+		}
+	}
+
+	for b.Loop() {
+		_, _ = EncodeCursors(datas)
+	}
+}
+
 func BenchmarkDecodeCursor(b *testing.B) {
 	cursor, _ := EncodeCursor(&CursorData[any]{
 		ID:        "user_123",
@@ -1,6 +1,9 @@
 package paginate
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors for pagination operations.
 // These can be checked using errors.Is() for proper error handling.
@@ -8,9 +11,18 @@ var (
 	// ErrInvalidPage indicates the page number is invalid (< 1).
 	ErrInvalidPage = errors.New("paginate: page must be >= 1")
 
+	// ErrPageOutOfRange indicates the page number is beyond the last page.
+	// It wraps ErrInvalidPage, so errors.Is(err, ErrInvalidPage) also matches.
+	ErrPageOutOfRange = fmt.Errorf("paginate: page exceeds total pages: %w", ErrInvalidPage)
+
 	// ErrInvalidPageSize indicates the page size is outside allowed bounds.
 	ErrInvalidPageSize = errors.New("paginate: page_size must be between min and max allowed values")
 
+	// ErrDisallowedPageSize indicates the page size is not one of the values
+	// an API permits. It wraps ErrInvalidPageSize, so
+	// errors.Is(err, ErrInvalidPageSize) also matches.
+	ErrDisallowedPageSize = fmt.Errorf("paginate: page_size is not an allowed value: %w", ErrInvalidPageSize)
+
 	// ErrInvalidCursor indicates the cursor is malformed or has been tampered with.
 	ErrInvalidCursor = errors.New("paginate: cursor is malformed or invalid")
 
@@ -19,4 +31,40 @@ var (
 
 	// ErrInvalidRange indicates the range parameters are invalid.
 	ErrInvalidRange = errors.New("paginate: invalid range parameters")
+
+	// ErrRelayArgsConflict indicates mutually exclusive Relay arguments were
+	// combined: forward ones (first/after) with backward ones (last/before).
+	ErrRelayArgsConflict = errors.New("paginate: first/last and after/before are mutually exclusive")
+
+	// ErrMultipleStrategies indicates a request combines parameters of more
+	// than one pagination strategy (e.g. a Range header and a page parameter).
+	ErrMultipleStrategies = errors.New("paginate: request mixes multiple pagination strategies")
+
+	// ErrInvalidTimeWindow indicates the time window bounds are malformed or
+	// since is after until.
+	ErrInvalidTimeWindow = errors.New("paginate: invalid time window")
+
+	// ErrCursorTooLarge indicates an encoded cursor exceeds MaxCursorBytes.
+	// Consider storing less state in the cursor or compressing it.
+	ErrCursorTooLarge = errors.New("paginate: encoded cursor exceeds maximum size")
+
+	// ErrInvalidSort indicates a sort token is malformed.
+	ErrInvalidSort = errors.New("paginate: invalid sort")
+
+	// ErrDuplicateParam indicates a pagination parameter was given more than
+	// once, e.g. ?page=2&page=3, which usually signals a client bug or an
+	// attempt at parameter smuggling.
+	ErrDuplicateParam = errors.New("paginate: duplicate pagination parameter")
+
+	// ErrSecureCursorKey indicates a secure cursor needs signing or
+	// encryption but no key was given.
+	ErrSecureCursorKey = errors.New("paginate: secure cursor key is empty")
+
+	// ErrResultCapExceeded indicates a walk over a paginated source hit its
+	// item cap before the source was exhausted.
+	ErrResultCapExceeded = errors.New("paginate: result cap exceeded")
+
+	// ErrNilPage indicates a fetch function returned neither a page nor an
+	// error.
+	ErrNilPage = errors.New("paginate: fetch returned a nil page")
 )
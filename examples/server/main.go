@@ -108,7 +108,7 @@ func handleCursorPagination(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Decode cursor if present
-	var startIdx int
+	startIdx, endIdx := 0, c.Limit
 	if c.HasCursor() {
 		cursorData, err := c.Decode()
 		if err != nil {
@@ -116,39 +116,45 @@ func handleCursorPagination(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Find start index from cursor
+		// Find the page bounds from the cursor
 		for i, u := range users {
 			if u.ID == cursorData.ID {
 				if c.Forward {
-					startIdx = i + 1
+					startIdx, endIdx = i+1, i+1+c.Limit
 				} else {
-					startIdx = i - c.Limit
-					if startIdx < 0 {
-						startIdx = 0
-					}
+					startIdx, endIdx = max(i-c.Limit, 0), i
 				}
 				break
 			}
 		}
 	}
 
+	// Paging backward from the first item yields an empty page, not forward
+	// results. IsBackwardStart covers offset cursors; ID cursors need the
+	// index check.
+	if c.IsBackwardStart() || (!c.Forward && c.HasCursor() && endIdx == 0) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(paginate.NewEmptyCursorPage[User](c.Limit)); err != nil {
+			log.Printf("failed to encode response: %v", err)
+		}
+		return
+	}
+
 	// Get items
-	endIdx := startIdx + c.Limit
 	if endIdx > len(users) {
 		endIdx = len(users)
 	}
 
 	pageUsers := users[startIdx:endIdx]
 
-	// Create cursors
+	// Create cursors from the boundary items
+	first, last := paginate.Cursors(pageUsers, userCursor)
 	var nextCursor, prevCursor string
 	if endIdx < len(users) {
-		lastUser := pageUsers[len(pageUsers)-1]
-		nextCursor, _ = paginate.NewCursorFromID(lastUser.ID)
+		nextCursor = last
 	}
-	if startIdx > 0 && len(pageUsers) > 0 {
-		firstUser := pageUsers[0]
-		prevCursor, _ = paginate.NewCursorFromID(firstUser.ID)
+	if startIdx > 0 {
+		prevCursor = first
 	}
 
 	// Create response
@@ -180,19 +186,12 @@ func handleRangePagination(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get ranged data
+	// Get ranged data, clamped to the available items
 	total := int64(len(users))
-	start := rng.Start
-	end := rng.End + 1 // Range is inclusive, slice is exclusive
-
-	if start >= total {
-		start = total
+	rangeUsers := []User{}
+	if avail, ok := rng.Intersect(paginate.NewRange(0, total-1)); ok {
+		rangeUsers = users[avail.Start : avail.End+1]
 	}
-	if end > total {
-		end = total
-	}
-
-	rangeUsers := users[start:end]
 
 	// Create response
 	response := paginate.NewRangeResponse(rangeUsers, rng, total)
@@ -202,18 +201,20 @@ func handleRangePagination(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Accept-Ranges", "items")
 	w.Header().Set("Content-Type", "application/json")
 
-	// Set status code
-	if start == 0 && end >= total {
-		w.WriteHeader(http.StatusOK)
-	} else {
-		w.WriteHeader(http.StatusPartialContent)
-	}
+	// Set status code (200, 206 or 416)
+	w.WriteHeader(response.StatusCode())
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		log.Printf("failed to encode response: %v", err)
 	}
 }
 
+// userCursor returns the cursor of a user.
+func userCursor(u User) string {
+	cursor, _ := paginate.NewCursorFromID(u.ID)
+	return cursor
+}
+
 // handleGraphQLConnection demonstrates GraphQL-style connections
 func handleGraphQLConnection(w http.ResponseWriter, r *http.Request) {
 	// Parse cursor parameters (supports GraphQL-style first/last/after/before)
@@ -236,10 +237,7 @@ func handleGraphQLConnection(w http.ResponseWriter, r *http.Request) {
 	// Create connection
 	conn := paginate.NewConnection(
 		pageUsers,
-		func(u User) string {
-			cursor, _ := paginate.NewCursorFromID(u.ID)
-			return cursor
-		},
+		userCursor,
 		false,              // hasPrev
 		limit < len(users), // hasNext
 		int64(len(users)),  // total
@@ -0,0 +1,334 @@
+package paginate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeysetCursor holds the sort column values of a row for keyset (seek)
+// pagination. Fields and Values are parallel slices in sort order.
+type KeysetCursor struct {
+	Fields []string `json:"f"`
+	Values []any    `json:"v"`
+
+	// SkipSameKey is the number of rows with exactly these Values that were
+	// already emitted. It lets pagination resume inside a run of duplicate
+	// keys at a page boundary; see BuildKeysetWhereSkip.
+	SkipSameKey int `json:"s,omitempty"`
+}
+
+// NewKeysetCursor builds a keyset cursor from the sort columns of the last
+// returned row and encodes it to a base64 string.
+// Returns an error if the number of fields and values differ or a value
+// cannot be marshaled to JSON.
+func NewKeysetCursor(fields []string, values []any) (string, error) {
+	if len(fields) != len(values) {
+		return "", fmt.Errorf("%w: got %d fields and %d values",
+			ErrInvalidCursor, len(fields), len(values))
+	}
+	return EncodeKeysetCursor(&KeysetCursor{Fields: fields, Values: values})
+}
+
+// EncodeKeysetCursor encodes a keyset cursor to a base64 string.
+// Returns an empty string and nil error if kc is nil.
+func EncodeKeysetCursor(kc *KeysetCursor) (string, error) {
+	if kc == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(kc)
+	if err != nil {
+		return "", err
+	}
+	return checkCursorSize(EncodeCursorRaw(b))
+}
+
+// ColumnType declares how NewKeysetCursorRaw interprets a raw column value.
+type ColumnType int
+
+// Column types supported by NewKeysetCursorRaw.
+const (
+	ColumnString ColumnType = iota
+	ColumnInt
+	ColumnTime
+)
+
+// String returns the name of the column type.
+func (t ColumnType) String() string {
+	switch t {
+	case ColumnString:
+		return "string"
+	case ColumnInt:
+		return "int"
+	case ColumnTime:
+		return "time"
+	default:
+		return "unknown"
+	}
+}
+
+// rawTimeLayouts are the textual timestamp formats drivers return for raw
+// columns: RFC 3339 and the SQL formats of PostgreSQL and MySQL.
+var rawTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// NewKeysetCursorRaw builds a keyset cursor from sort column values scanned
+// as raw bytes (e.g. sql.RawBytes), interpreting each per its declared type,
+// so callers need not materialize typed values just to build a cursor.
+// Integers become int64 and timestamps UTC time.Time; timestamps without a
+// zone are taken as UTC. A nil value stands for NULL. The raw bytes are
+// copied, so sql.RawBytes may be reused after the call.
+// Returns ErrInvalidCursor if the slices differ in length or a value does
+// not match its declared type.
+func NewKeysetCursorRaw(columns []string, raw [][]byte, types []ColumnType) (string, error) {
+	if len(columns) != len(raw) || len(columns) != len(types) {
+		return "", fmt.Errorf("%w: got %d columns, %d values and %d types",
+			ErrInvalidCursor, len(columns), len(raw), len(types))
+	}
+
+	values := make([]any, len(raw))
+	for i, b := range raw {
+		v, err := parseRawColumn(b, types[i])
+		if err != nil {
+			return "", fmt.Errorf("%w: column %q: %v", ErrInvalidCursor, columns[i], err)
+		}
+		values[i] = v
+	}
+	return NewKeysetCursor(columns, values)
+}
+
+// parseRawColumn converts a raw column value to the Go type of t.
+func parseRawColumn(b []byte, t ColumnType) (any, error) {
+	if b == nil {
+		return nil, nil
+	}
+	switch t {
+	case ColumnString:
+		return string(b), nil
+	case ColumnInt:
+		n, err := strconv.ParseInt(string(b), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an int", b)
+		}
+		return n, nil
+	case ColumnTime:
+		for _, layout := range rawTimeLayouts {
+			if ts, err := time.Parse(layout, string(b)); err == nil {
+				return ts.UTC(), nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a time", b)
+	default:
+		return nil, fmt.Errorf("unknown column type %d", t)
+	}
+}
+
+// DecodeKeysetCursor decodes a base64 keyset cursor string.
+// Returns an error if the cursor is malformed or its fields and values
+// have different lengths.
+func DecodeKeysetCursor(cursor string) (*KeysetCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	b, err := DecodeCursorRaw(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var kc KeysetCursor
+	if err := json.Unmarshal(b, &kc); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if len(kc.Fields) != len(kc.Values) {
+		return nil, ErrInvalidCursor
+	}
+
+	return &kc, nil
+}
+
+// KeysetField describes a sort column for BuildKeysetWhere.
+//
+// For nullable columns, NullsFirst must match where the database places NULLs
+// in the ORDER BY used for the query:
+//   - PostgreSQL treats NULL as larger than any value: NULLS LAST for ASC and
+//     NULLS FIRST for DESC by default, overridable with NULLS FIRST/LAST.
+//   - MySQL and SQLite treat NULL as smaller than any value: NULLS FIRST for
+//     ASC and NULLS LAST for DESC. MySQL has no NULLS FIRST/LAST syntax.
+type KeysetField struct {
+	Column     string
+	Desc       bool
+	Nullable   bool
+	NullsFirst bool
+}
+
+// KeysetFieldsFromSort converts a sort order into keyset fields for
+// BuildKeysetWhere, first applying EnsureTieBreak with uniqueField so rows
+// with equal sort values are neither skipped nor repeated. The cursor values
+// must follow the returned field order, including the tie-break column.
+// Nullable columns must be marked on the result by the caller.
+func KeysetFieldsFromSort(sort Sort, uniqueField string) []KeysetField {
+	sort = EnsureTieBreak(sort, uniqueField)
+	fields := make([]KeysetField, len(sort))
+	for i, f := range sort {
+		fields[i] = KeysetField{Column: f.Field, Desc: f.Desc}
+	}
+	return fields
+}
+
+// BuildKeysetWhere builds the condition selecting rows that sort after the
+// row whose sort column values are given, for keyset (seek) pagination. The
+// condition uses ? placeholders and is returned without the WHERE keyword,
+// together with its arguments. A nil value stands for NULL.
+//
+// For nullable fields the NULL group is paged past with IS NULL / IS NOT NULL
+// predicates, so rows are neither skipped nor repeated around it.
+// Returns ErrInvalidCursor if fields and values differ in length or a
+// non-nullable field has a nil value.
+func BuildKeysetWhere(fields []KeysetField, values []any) (string, []any, error) {
+	if len(fields) != len(values) {
+		return "", nil, fmt.Errorf("%w: got %d fields and %d values",
+			ErrInvalidCursor, len(fields), len(values))
+	}
+	if len(fields) == 0 {
+		return "", nil, nil
+	}
+
+	var terms []string
+	var args []any
+	for i, f := range fields {
+		if values[i] == nil && !f.Nullable {
+			return "", nil, fmt.Errorf("%w: NULL value for non-nullable column %s",
+				ErrInvalidCursor, f.Column)
+		}
+
+		after, afterArgs := keysetAfter(f, values[i])
+		if after == "" {
+			// Nothing sorts after a trailing NULL group in this column.
+			continue
+		}
+
+		conds := make([]string, 0, i+1)
+		for j := range i {
+			if values[j] == nil {
+				conds = append(conds, fields[j].Column+" IS NULL")
+			} else {
+				conds = append(conds, fields[j].Column+" = ?")
+				args = append(args, values[j])
+			}
+		}
+		if i > 0 && strings.Contains(after, " OR ") {
+			after = "(" + after + ")"
+		}
+		conds = append(conds, after)
+		args = append(args, afterArgs...)
+		terms = append(terms, "("+strings.Join(conds, " AND ")+")")
+	}
+
+	if len(terms) == 0 {
+		return "1 = 0", nil, nil
+	}
+	return strings.Join(terms, " OR "), args, nil
+}
+
+// BuildKeysetWhereSkip builds the keyset condition for sort keys that are
+// not unique, e.g. a score without an id tie-break. With a strict "after"
+// condition, rows sharing the boundary key that did not fit on the previous
+// page would be skipped; with "after or equal" they would be repeated.
+//
+// If skip is 0 it is equivalent to BuildKeysetWhere. Otherwise the condition
+// also selects rows equal to the boundary key and the returned offset is
+// skip, to be applied as OFFSET so the boundary rows already emitted are
+// passed over:
+//
+//	where, args, offset, err := paginate.BuildKeysetWhereSkip(fields, kc.Values, kc.SkipSameKey)
+//	// SELECT ... WHERE <where> ORDER BY score LIMIT 20 OFFSET <offset>
+//
+// Build the next cursor with NewKeysetCursorSkip so skip accounts for runs
+// spanning several pages.
+func BuildKeysetWhereSkip(fields []KeysetField, values []any, skip int) (where string, args []any, offset int, err error) {
+	where, args, err = BuildKeysetWhere(fields, values)
+	if err != nil || skip <= 0 || len(fields) == 0 {
+		return where, args, 0, err
+	}
+
+	conds := make([]string, len(fields))
+	var eqArgs []any
+	for i, f := range fields {
+		if values[i] == nil {
+			conds[i] = f.Column + " IS NULL"
+		} else {
+			conds[i] = f.Column + " = ?"
+			eqArgs = append(eqArgs, values[i])
+		}
+	}
+	same := "(" + strings.Join(conds, " AND ") + ")"
+	if where == "1 = 0" {
+		return same, eqArgs, skip, nil
+	}
+	return where + " OR " + same, append(args, eqArgs...), skip, nil
+}
+
+// NewKeysetCursorSkip builds the keyset cursor following items, taking the
+// sort column values of the last item from keyFn and recording in
+// SkipSameKey how many rows with that key have been emitted: the trailing
+// run of equal keys on this page, plus prev.SkipSameKey if the whole page
+// continues the run of the previous cursor. prev is the cursor the page was
+// fetched with, or nil for the first page.
+// Returns an empty string if items is empty.
+func NewKeysetCursorSkip[T any](items []T, fields []string, keyFn func(T) []any, prev *KeysetCursor) (string, error) {
+	if len(items) == 0 {
+		return "", nil
+	}
+	last := keyFn(items[len(items)-1])
+	if len(fields) != len(last) {
+		return "", fmt.Errorf("%w: got %d fields and %d values",
+			ErrInvalidCursor, len(fields), len(last))
+	}
+	lastKey, err := json.Marshal(last)
+	if err != nil {
+		return "", err
+	}
+
+	sameKey := func(values []any) bool {
+		b, err := json.Marshal(values)
+		return err == nil && string(b) == string(lastKey)
+	}
+	run := 1
+	for run < len(items) && sameKey(keyFn(items[len(items)-1-run])) {
+		run++
+	}
+	if run == len(items) && prev != nil && sameKey(prev.Values) {
+		run += prev.SkipSameKey
+	}
+
+	return EncodeKeysetCursor(&KeysetCursor{Fields: fields, Values: last, SkipSameKey: run})
+}
+
+// keysetAfter returns the predicate selecting values of f that sort strictly
+// after v, or "" if none can.
+func keysetAfter(f KeysetField, v any) (string, []any) {
+	op := " > ?"
+	if f.Desc {
+		op = " < ?"
+	}
+
+	switch {
+	case !f.Nullable:
+		return f.Column + op, []any{v}
+	case v == nil && f.NullsFirst:
+		return f.Column + " IS NOT NULL", nil
+	case v == nil:
+		return "", nil
+	case f.NullsFirst:
+		return f.Column + op, []any{v}
+	default:
+		return f.Column + op + " OR " + f.Column + " IS NULL", []any{v}
+	}
+}
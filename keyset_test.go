@@ -0,0 +1,352 @@
+package paginate
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestNewKeysetCursor(t *testing.T) {
+	cursor, err := NewKeysetCursor([]string{"created_at", "id"}, []any{"2024-01-01T00:00:00Z", 42})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cursor == "" {
+		t.Fatal("Expected non-empty cursor")
+	}
+
+	kc, err := DecodeKeysetCursor(cursor)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	if len(kc.Fields) != 2 || kc.Fields[0] != "created_at" || kc.Fields[1] != "id" {
+		t.Errorf("Unexpected fields: %v", kc.Fields)
+	}
+	if kc.Values[0] != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected first value '2024-01-01T00:00:00Z', got %v", kc.Values[0])
+	}
+	if kc.Values[1] != float64(42) {
+		t.Errorf("Expected second value 42, got %v", kc.Values[1])
+	}
+}
+
+func TestNewKeysetCursorErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		values []any
+	}{
+		{"Length mismatch", []string{"id"}, []any{1, 2}},
+		{"Not serializable", []string{"ch"}, []any{make(chan int)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeysetCursor(tt.fields, tt.values); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	_, err := NewKeysetCursor([]string{"id"}, nil)
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestNewKeysetCursorRaw(t *testing.T) {
+	columns := []string{"name", "id", "created_at", "updated_at", "deleted_at"}
+	raw := [][]byte{
+		[]byte("alice"),
+		[]byte("42"),
+		[]byte("2024-01-01 12:00:00.5+02"),
+		[]byte("2024-01-01 10:00:00"),
+		nil,
+	}
+	types := []ColumnType{ColumnString, ColumnInt, ColumnTime, ColumnTime, ColumnTime}
+
+	cursor, err := NewKeysetCursorRaw(columns, raw, types)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	kc, err := DecodeKeysetCursor(cursor)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	expected := []any{"alice", float64(42), "2024-01-01T10:00:00.5Z", "2024-01-01T10:00:00Z", nil}
+	for i, v := range expected {
+		if kc.Values[i] != v {
+			t.Errorf("Expected value %d to be %v, got %v", i, v, kc.Values[i])
+		}
+	}
+}
+
+func TestNewKeysetCursorRawErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   [][]byte
+		types []ColumnType
+	}{
+		{"Length mismatch", [][]byte{[]byte("1")}, []ColumnType{ColumnInt, ColumnInt}},
+		{"Not an int", [][]byte{[]byte("abc")}, []ColumnType{ColumnInt}},
+		{"Not a time", [][]byte{[]byte("yesterday")}, []ColumnType{ColumnTime}},
+		{"Unknown type", [][]byte{[]byte("1")}, []ColumnType{ColumnType(99)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := make([]string, len(tt.raw))
+			_, err := NewKeysetCursorRaw(columns, tt.raw, tt.types)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeKeysetCursorInvalid(t *testing.T) {
+	mismatched, err := EncodeKeysetCursor(&KeysetCursor{Fields: []string{"id"}})
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"Invalid base64", "not-valid-base64!!!"},
+		{"Invalid JSON", "dGhpcyBpcyBub3QganNvbg=="},
+		{"Length mismatch", mismatched},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeKeysetCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+
+	kc, err := DecodeKeysetCursor("")
+	if err != nil || kc != nil {
+		t.Errorf("Expected nil, nil for empty cursor, got %v, %v", kc, err)
+	}
+}
+
+func TestBuildKeysetWhere(t *testing.T) {
+	id := KeysetField{Column: "id"}
+
+	tests := []struct {
+		name     string
+		fields   []KeysetField
+		values   []any
+		expected string
+		args     []any
+	}{
+		{
+			"Single column", []KeysetField{id}, []any{5},
+			"(id > ?)", []any{5},
+		},
+		{
+			"Descending with tie-break",
+			[]KeysetField{{Column: "score", Desc: true}, id}, []any{90, 5},
+			"(score < ?) OR (score = ? AND id > ?)", []any{90, 90, 5},
+		},
+		{
+			"Nullable nulls last",
+			[]KeysetField{{Column: "due", Nullable: true}, id}, []any{"2024-01-01", 5},
+			"(due > ? OR due IS NULL) OR (due = ? AND id > ?)", []any{"2024-01-01", "2024-01-01", 5},
+		},
+		{
+			"Nullable second column",
+			[]KeysetField{{Column: "team"}, {Column: "due", Nullable: true}, id}, []any{"a", "2024-01-01", 5},
+			"(team > ?) OR (team = ? AND (due > ? OR due IS NULL)) OR (team = ? AND due = ? AND id > ?)",
+			[]any{"a", "a", "2024-01-01", "a", "2024-01-01", 5},
+		},
+		{
+			"Nullable nulls last in NULL group",
+			[]KeysetField{{Column: "due", Nullable: true}, id}, []any{nil, 5},
+			"(due IS NULL AND id > ?)", []any{5},
+		},
+		{
+			"Nullable nulls first",
+			[]KeysetField{{Column: "due", Nullable: true, NullsFirst: true}, id}, []any{"2024-01-01", 5},
+			"(due > ?) OR (due = ? AND id > ?)", []any{"2024-01-01", "2024-01-01", 5},
+		},
+		{
+			"Nullable nulls first in NULL group",
+			[]KeysetField{{Column: "due", Desc: true, Nullable: true, NullsFirst: true}, id}, []any{nil, 5},
+			"(due IS NOT NULL) OR (due IS NULL AND id > ?)", []any{5},
+		},
+		{
+			"Trailing NULL group",
+			[]KeysetField{{Column: "due", Nullable: true}}, []any{nil},
+			"1 = 0", nil,
+		},
+		{
+			"No fields", nil, nil,
+			"", nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := BuildKeysetWhere(tt.fields, tt.values)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if where != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, where)
+			}
+			if len(args) != len(tt.args) {
+				t.Fatalf("Expected args %v, got %v", tt.args, args)
+			}
+			for i := range args {
+				if args[i] != tt.args[i] {
+					t.Errorf("Expected args %v, got %v", tt.args, args)
+				}
+			}
+		})
+	}
+}
+
+func TestKeysetFieldsFromSort(t *testing.T) {
+	fields := KeysetFieldsFromSort(Sort{{Field: "score", Desc: true}}, "id")
+	expected := []KeysetField{{Column: "score", Desc: true}, {Column: "id"}}
+	if !slices.Equal(fields, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, fields)
+	}
+
+	where, _, err := BuildKeysetWhere(fields, []any{90, 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if where != "(score < ?) OR (score = ? AND id > ?)" {
+		t.Errorf("Expected tie-broken condition, got %q", where)
+	}
+
+	fields = KeysetFieldsFromSort(Sort{{Field: "id", Desc: true}, {Field: "name"}}, "id")
+	if len(fields) != 2 || fields[0] != (KeysetField{Column: "id", Desc: true}) {
+		t.Errorf("Expected existing tie-break to be kept, got %+v", fields)
+	}
+}
+
+func TestBuildKeysetWhereSkip(t *testing.T) {
+	id := KeysetField{Column: "id"}
+
+	tests := []struct {
+		name     string
+		fields   []KeysetField
+		values   []any
+		skip     int
+		expected string
+		args     []any
+	}{
+		{"No skip", []KeysetField{{Column: "score"}}, []any{3}, 0, "(score > ?)", []any{3}},
+		{"Skip", []KeysetField{{Column: "score"}}, []any{3}, 2, "(score > ?) OR (score = ?)", []any{3, 3}},
+		{
+			"Skip with two columns", []KeysetField{{Column: "team"}, id}, []any{"a", 5}, 1,
+			"(team > ?) OR (team = ? AND id > ?) OR (team = ? AND id = ?)", []any{"a", "a", 5, "a", 5},
+		},
+		{
+			"Skip in trailing NULL group", []KeysetField{{Column: "due", Nullable: true}}, []any{nil}, 1,
+			"(due IS NULL)", nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, offset, err := BuildKeysetWhereSkip(tt.fields, tt.values, tt.skip)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if where != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, where)
+			}
+			if offset != tt.skip {
+				t.Errorf("Expected offset %d, got %d", tt.skip, offset)
+			}
+			if len(args) != len(tt.args) {
+				t.Fatalf("Expected args %v, got %v", tt.args, args)
+			}
+			for i := range args {
+				if args[i] != tt.args[i] {
+					t.Errorf("Expected args %v, got %v", tt.args, args)
+				}
+			}
+		})
+	}
+}
+
+// TestKeysetSkipSameKeyWalk pages through rows whose non-unique sort key has
+// five duplicates straddling page boundaries, evaluating the condition for
+// "(score > ?) OR (score = ?)" in memory.
+func TestKeysetSkipSameKeyWalk(t *testing.T) {
+	scores := []int{1, 2, 3, 3, 3, 3, 3, 4, 5}
+	fields := []KeysetField{{Column: "score"}}
+	keyFn := func(score int) []any { return []any{score} }
+
+	for _, limit := range []int{2, 3, 4} {
+		t.Run(strconv.Itoa(limit), func(t *testing.T) {
+			var walked []int
+			var kc *KeysetCursor
+			for range len(scores) + 1 {
+				rows := scores
+				offset := 0
+				if kc != nil {
+					where, _, off, err := BuildKeysetWhereSkip(fields, kc.Values, kc.SkipSameKey)
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					boundary := int(kc.Values[0].(float64))
+					inclusive := where == "(score > ?) OR (score = ?)"
+					rows = nil
+					for _, s := range scores {
+						if s > boundary || (inclusive && s == boundary) {
+							rows = append(rows, s)
+						}
+					}
+					offset = off
+				}
+				page := rows[min(offset, len(rows)):min(offset+limit, len(rows))]
+				if len(page) == 0 {
+					break
+				}
+				walked = append(walked, page...)
+
+				cursor, err := NewKeysetCursorSkip(page, []string{"score"}, keyFn, kc)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if kc, err = DecodeKeysetCursor(cursor); err != nil {
+					t.Fatalf("Unexpected decode error: %v", err)
+				}
+			}
+
+			if !slices.Equal(walked, scores) {
+				t.Errorf("Expected %v, got %v", scores, walked)
+			}
+		})
+	}
+}
+
+func TestBuildKeysetWhereErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []KeysetField
+		values []any
+	}{
+		{"Length mismatch", []KeysetField{{Column: "id"}}, []any{1, 2}},
+		{"NULL in non-nullable column", []KeysetField{{Column: "id"}}, []any{nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := BuildKeysetWhere(tt.fields, tt.values); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package paginate
+
+// OpenAPIParam describes a pagination parameter in OpenAPI terms.
+// It mirrors the OpenAPI 3 Parameter Object so spec generators can emit
+// definitions that stay in sync with the package's validation bounds.
+type OpenAPIParam struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required"`
+	Schema      OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema describes the schema of a pagination parameter.
+type OpenAPISchema struct {
+	Type    string   `json:"type"`
+	Pattern string   `json:"pattern,omitempty"`
+	Enum    []string `json:"enum,omitempty"`
+	Minimum *int     `json:"minimum,omitempty"`
+	Maximum *int     `json:"maximum,omitempty"`
+	Default any      `json:"default,omitempty"`
+}
+
+// OffsetParams returns the parameters accepted by FromQuery.
+func OffsetParams() []OpenAPIParam {
+	return Options{}.OffsetParams()
+}
+
+// OffsetParams returns the parameters accepted by Options.FromQuery, with
+// the page minimum and default following ZeroBased. The page size minimum is
+// 0 if AllowUnlimited is set, as 0 then requests all items.
+func (o Options) OffsetParams() []OpenAPIParam {
+	return []OpenAPIParam{
+		{
+			Name:        "page",
+			In:          "query",
+			Description: "Page number.",
+			Schema:      OpenAPISchema{Type: "integer", Minimum: intPtr(o.firstPage()), Default: o.firstPage()},
+		},
+		offsetPageSizeParam("page_size", "Number of items per page.", o.AllowUnlimited),
+		offsetPageSizeParam("limit", "Alias of page_size.", o.AllowUnlimited),
+		offsetPageSizeParam("per_page", "Alias of page_size.", o.AllowUnlimited),
+		{
+			Name:        "offset",
+			In:          "query",
+			Description: "Item offset, rounded down to the containing page. Ignored if page is given.",
+			Schema:      OpenAPISchema{Type: "integer", Minimum: intPtr(0)},
+		},
+	}
+}
+
+// CursorParams returns the parameters accepted by CursorFromQuery.
+func CursorParams() []OpenAPIParam {
+	return []OpenAPIParam{
+		cursorParam("cursor", "Opaque cursor to continue from."),
+		cursorParam("after", "Return items after this cursor."),
+		cursorParam("before", "Return items before this cursor."),
+		pageSizeParam("limit", "Maximum number of items to return."),
+		pageSizeParam("first", "Return the first N items after the cursor (GraphQL-style)."),
+		pageSizeParam("last", "Return the last N items before the cursor (GraphQL-style)."),
+		{
+			Name:        "direction",
+			In:          "query",
+			Description: "Direction to page from cursor. Ignored if after, before, first or last is given.",
+			Schema: OpenAPISchema{
+				Type:    "string",
+				Enum:    []string{"forward", "backward", "next", "prev"},
+				Default: "forward",
+			},
+		},
+	}
+}
+
+// RangeParams returns the parameters accepted by RangeFromRequest.
+func RangeParams() []OpenAPIParam {
+	return []OpenAPIParam{
+		{
+			Name:        "Range",
+			In:          "header",
+			Description: "Requested item range, e.g. items=0-24.",
+			Schema:      OpenAPISchema{Type: "string", Pattern: rangeRegex.String()},
+		},
+	}
+}
+
+// pageSizeParam describes an integer query parameter bounded by the page size limits.
+func pageSizeParam(name, description string) OpenAPIParam {
+	return OpenAPIParam{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema: OpenAPISchema{
+			Type:    "integer",
+			Minimum: intPtr(MinPageSize),
+			Maximum: intPtr(MaxPageSize),
+			Default: DefaultPageSize,
+		},
+	}
+}
+
+// offsetPageSizeParam describes a page size parameter of FromQuery, which
+// accepts 0 for unlimited pages if allowUnlimited is set.
+func offsetPageSizeParam(name, description string, allowUnlimited bool) OpenAPIParam {
+	param := pageSizeParam(name, description)
+	if allowUnlimited {
+		param.Schema.Minimum = intPtr(0)
+		param.Description += " 0 returns all items."
+	}
+	return param
+}
+
+// cursorParam describes an opaque cursor query parameter.
+func cursorParam(name, description string) OpenAPIParam {
+	return OpenAPIParam{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      OpenAPISchema{Type: "string"},
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,85 @@
+package paginate
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestOffsetParams(t *testing.T) {
+	params := OffsetParams()
+	if len(params) != 5 {
+		t.Fatalf("Expected 5 params, got %d", len(params))
+	}
+	for i, name := range []string{"page", "page_size", "limit", "per_page", "offset"} {
+		if params[i].Name != name {
+			t.Errorf("Expected param %d to be %s, got %s", i, name, params[i].Name)
+		}
+	}
+	if offset := params[4]; offset.Schema.Minimum == nil || *offset.Schema.Minimum != 0 {
+		t.Error("Expected offset minimum 0")
+	}
+
+	page := params[0]
+	if page.Name != "page" || page.In != "query" {
+		t.Errorf("Unexpected page param: %+v", page)
+	}
+	if page.Schema.Minimum == nil || *page.Schema.Minimum != 1 {
+		t.Error("Expected page minimum 1")
+	}
+
+	size := params[1]
+	if size.Name != "page_size" {
+		t.Errorf("Expected page_size, got %s", size.Name)
+	}
+	if size.Schema.Minimum == nil || *size.Schema.Minimum != MinPageSize {
+		t.Errorf("Expected page_size minimum %d", MinPageSize)
+	}
+	if size.Schema.Maximum == nil || *size.Schema.Maximum != MaxPageSize {
+		t.Errorf("Expected page_size maximum %d", MaxPageSize)
+	}
+	if size.Schema.Default != DefaultPageSize {
+		t.Errorf("Expected page_size default %d, got %v", DefaultPageSize, size.Schema.Default)
+	}
+
+	for _, p := range (Options{AllowUnlimited: true}).OffsetParams()[1:4] {
+		if p.Schema.Minimum == nil || *p.Schema.Minimum != 0 {
+			t.Errorf("Expected %s minimum 0 with AllowUnlimited", p.Name)
+		}
+	}
+}
+
+func TestCursorParams(t *testing.T) {
+	names := map[string]bool{}
+	for _, p := range CursorParams() {
+		names[p.Name] = true
+		if p.In != "query" {
+			t.Errorf("Expected %s in query, got %s", p.Name, p.In)
+		}
+	}
+
+	for _, name := range []string{"cursor", "after", "before", "limit", "first", "last", "direction"} {
+		if !names[name] {
+			t.Errorf("Missing cursor param %s", name)
+		}
+	}
+
+	direction := CursorParams()[len(CursorParams())-1]
+	for _, value := range direction.Schema.Enum {
+		if c := CursorFromQuery(url.Values{"cursor": {"x"}, "direction": {value}}); c.Forward != (value == "forward" || value == "next") {
+			t.Errorf("Expected direction %q to be parsed, got forward=%v", value, c.Forward)
+		}
+	}
+}
+
+func TestRangeParams(t *testing.T) {
+	params := RangeParams()
+	if len(params) != 1 {
+		t.Fatalf("Expected 1 param, got %d", len(params))
+	}
+	if params[0].Name != "Range" || params[0].In != "header" {
+		t.Errorf("Unexpected range param: %+v", params[0])
+	}
+	if params[0].Schema.Pattern == "" {
+		t.Error("Expected range pattern")
+	}
+}
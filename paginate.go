@@ -1,10 +1,15 @@
 package paginate
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"mime"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 // Default pagination values.
@@ -15,19 +20,61 @@ const (
 	MinPageSize     = 1
 )
 
+// Options configures the page numbering and page size limits of a
+// Paginator. The zero value gives 1-based pages and no unlimited page size.
+// Options are carried by each paginator, so one program can serve clients
+// with different conventions.
+type Options struct {
+	// ZeroBased selects 0-based page numbers for clients that count pages
+	// from 0: the first page is 0, WithPage accepts 0, and Offset computes
+	// Page*PageSize.
+	ZeroBased bool
+
+	// AllowUnlimited treats page_size=0 as "no limit": WithPageSize(0) and a
+	// page_size=0 query parameter produce an unlimited paginator, whose
+	// Limit returns NoLimit and whose SQL clauses omit LIMIT.
+	AllowUnlimited bool
+}
+
+// firstPage returns the number of the first page: 0 if ZeroBased is set,
+// otherwise DefaultPage.
+func (o Options) firstPage() int {
+	if o.ZeroBased {
+		return 0
+	}
+	return DefaultPage
+}
+
+// NoLimit is returned by Limit for unlimited paginators.
+const NoLimit = -1
+
+// UnknownTotal is the total count to pass when the size of the collection is
+// not known (e.g. count-free paging). All total-aware helpers and response
+// builders treat negative totals this way.
+const UnknownTotal int64 = -1
+
 // Paginator represents offset-based pagination parameters.
 // Instances are safe to read concurrently. Use With* methods to create
 // modified copies for thread-safe updates.
 type Paginator struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
+
+	opts Options
 }
 
 // New creates a new Paginator with default values.
 func New() *Paginator {
+	return Options{}.New()
+}
+
+// New creates a new Paginator with default values and the options.
+// The page is the first page, which is 0 if ZeroBased is set.
+func (o Options) New() *Paginator {
 	return &Paginator{
-		Page:     DefaultPage,
+		Page:     o.firstPage(),
 		PageSize: DefaultPageSize,
+		opts:     o,
 	}
 }
 
@@ -42,21 +89,50 @@ func NewFromValues(page, pageSize int) *Paginator {
 	return New().WithPage(page).WithPageSize(pageSize)
 }
 
+// Options returns the options the paginator was created with.
+func (p *Paginator) Options() Options {
+	return p.opts
+}
+
+// WithOptions returns a new paginator with the specified options. The page
+// is renumbered so it addresses the same items, e.g. page 3 becomes page 2
+// when switching to ZeroBased. An unlimited page size falls back to
+// DefaultPageSize if the new options do not allow it.
+func (p *Paginator) WithOptions(opts Options) *Paginator {
+	clone := p.Clone()
+	clone.Page = p.Page - p.firstPage() + opts.firstPage()
+	clone.opts = opts
+	if clone.PageSize == 0 && !opts.AllowUnlimited {
+		clone.PageSize = DefaultPageSize
+	}
+	return clone
+}
+
+// firstPage returns the number of the paginator's first page.
+func (p *Paginator) firstPage() int {
+	return p.opts.firstPage()
+}
+
 // WithPage returns a new paginator with the specified page number.
 // This method is thread-safe as it returns a new instance.
 func (p *Paginator) WithPage(page int) *Paginator {
 	clone := p.Clone()
-	if page < 1 {
-		page = DefaultPage
+	if page < p.firstPage() {
+		page = p.firstPage()
 	}
 	clone.Page = page
 	return clone
 }
 
 // WithPageSize returns a new paginator with the specified page size.
+// A size of 0 means unlimited if AllowUnlimited is set.
 // This method is thread-safe as it returns a new instance.
 func (p *Paginator) WithPageSize(size int) *Paginator {
 	clone := p.Clone()
+	if size == 0 && p.opts.AllowUnlimited {
+		clone.PageSize = 0
+		return clone
+	}
 	if size < MinPageSize {
 		size = DefaultPageSize
 	}
@@ -67,49 +143,187 @@ func (p *Paginator) WithPageSize(size int) *Paginator {
 	return clone
 }
 
+// WithDefaults returns a new paginator with Page and PageSize coerced into
+// the valid range using the same rules as WithPage and WithPageSize.
+// Use it to normalize paginators built without the With* methods, e.g. via
+// json.Unmarshal.
+func (p *Paginator) WithDefaults() *Paginator {
+	return p.WithPage(p.Page).WithPageSize(p.PageSize)
+}
+
+// UnmarshalJSON decodes a paginator and clamps it like WithDefaults, so a
+// paginator decoded from untrusted input is always safe to use.
+// Missing fields take their default values. The receiver's options are kept,
+// so decode into Options.New() to read 0-based pages.
+func (p *Paginator) UnmarshalJSON(data []byte) error {
+	type rawPaginator Paginator
+	raw := rawPaginator{Page: p.firstPage(), PageSize: DefaultPageSize, opts: p.opts}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = *(*Paginator)(&raw).WithDefaults()
+	return nil
+}
+
 // Offset returns the offset for SQL queries.
 // Uses int64 to prevent overflow with large page numbers.
 func (p *Paginator) Offset() int64 {
-	return int64(p.Page-1) * int64(p.PageSize)
+	return p.pageIndex() * int64(p.PageSize)
+}
+
+// pageIndex returns the 0-based index of the current page.
+func (p *Paginator) pageIndex() int64 {
+	return int64(p.Page) - int64(p.firstPage())
 }
 
 // Limit returns the limit for SQL queries.
+// Returns NoLimit for unlimited paginators.
 func (p *Paginator) Limit() int {
+	if p.IsUnlimited() {
+		return NoLimit
+	}
 	return p.PageSize
 }
 
+// IsUnlimited returns true if the paginator has no page size limit.
+// This is only possible when the AllowUnlimited option is set.
+func (p *Paginator) IsUnlimited() bool {
+	return p.opts.AllowUnlimited && p.PageSize == 0
+}
+
 // Validate validates the pagination parameters.
 func (p *Paginator) Validate() error {
-	if p.Page < 1 {
+	if p.Page < p.firstPage() {
 		return fmt.Errorf("%w: got %d", ErrInvalidPage, p.Page)
 	}
+	if p.IsUnlimited() {
+		return nil
+	}
 	if p.PageSize < MinPageSize || p.PageSize > MaxPageSize {
 		return fmt.Errorf("%w: got %d, allowed range [%d, %d]",
 			ErrInvalidPageSize, p.PageSize, MinPageSize, MaxPageSize)
 	}
+	if p.OffsetOverflows() {
+		return fmt.Errorf("%w: got %d, offset overflows", ErrInvalidPage, p.Page)
+	}
+	return nil
+}
+
+// OffsetOverflows returns true if the offset of the current page,
+// (Page-1)*PageSize, does not fit in an int64. The check is done without
+// performing the overflowing multiplication.
+func (p *Paginator) OffsetOverflows() bool {
+	index, size := p.pageIndex(), int64(p.PageSize)
+	if index <= 0 || size <= 0 {
+		return false
+	}
+	return index > math.MaxInt64/size
+}
+
+// ValidateAllowedSizes returns ErrDisallowedPageSize, listing the permitted
+// values, if PageSize is not one of sizes. Call it after FromRequest for APIs
+// that only accept discrete page sizes (e.g. 10, 25, 50, 100). Any page size
+// is allowed if sizes is empty.
+func (p *Paginator) ValidateAllowedSizes(sizes ...int) error {
+	if len(sizes) == 0 || slices.Contains(sizes, p.PageSize) {
+		return nil
+	}
+	return fmt.Errorf("%w: got %d, allowed %v", ErrDisallowedPageSize, p.PageSize, sizes)
+}
+
+// SnapPageSize returns a new paginator whose PageSize is the allowed size
+// closest to the requested one, for clients such as sliders that cannot
+// guarantee exact values. Ties round down to the smaller size. The page size
+// is unchanged if sizes is empty or the paginator is unlimited.
+func (p *Paginator) SnapPageSize(sizes ...int) *Paginator {
+	clone := p.Clone()
+	if len(sizes) == 0 || p.IsUnlimited() {
+		return clone
+	}
+	best := sizes[0]
+	for _, size := range sizes[1:] {
+		d, bestD := abs(size-p.PageSize), abs(best-p.PageSize)
+		if d < bestD || (d == bestD && size < best) {
+			best = size
+		}
+	}
+	clone.PageSize = best
+	return clone
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// CheckBounds returns ErrPageOutOfRange if the page is beyond the last page
+// for the given total count. An empty collection (total <= 0) is never out
+// of range, so page 1 of an empty result remains valid.
+func (p *Paginator) CheckBounds(total int64) error {
+	if total <= 0 {
+		return nil
+	}
+	if totalPages := p.TotalPages(total); p.pageIndex() >= int64(totalPages) {
+		return fmt.Errorf("%w: got %d, total pages %d", ErrPageOutOfRange, p.Page, totalPages)
+	}
 	return nil
 }
 
 // SQLClause returns SQL LIMIT OFFSET clause (PostgreSQL style).
+// Returns an empty string for unlimited paginators.
 func (p *Paginator) SQLClause() string {
+	if p.IsUnlimited() {
+		return ""
+	}
 	return fmt.Sprintf("LIMIT %d OFFSET %d", p.Limit(), p.Offset())
 }
 
 // SQLClauseMySQL returns MySQL-style LIMIT clause.
+// Returns an empty string for unlimited paginators.
 func (p *Paginator) SQLClauseMySQL() string {
+	if p.IsUnlimited() {
+		return ""
+	}
 	return fmt.Sprintf("LIMIT %d, %d", p.Offset(), p.Limit())
 }
 
+// SQLRowNumberClause returns the WHERE bounds for ROW_NUMBER() pagination,
+// for databases without LIMIT/OFFSET or OFFSET/FETCH (older SQL Server, DB2).
+// The caller wraps the query in a subquery numbering its rows by orderBy and
+// appends the clause to the outer query:
+//
+//	SELECT * FROM (
+//		SELECT u.*, ROW_NUMBER() OVER (ORDER BY created_at DESC, id) AS rn
+//		FROM users u
+//	) t
+//	WHERE rn BETWEEN 21 AND 30
+//	ORDER BY rn
+//
+// Row numbers start at 1. orderBy is only checked for emptiness and is not
+// embedded in the clause; the caller must use it in the OVER clause. Returns
+// an empty string for unlimited paginators, or if orderBy is empty, since
+// ROW_NUMBER needs a deterministic order for pages to be stable.
+func (p *Paginator) SQLRowNumberClause(orderBy string) string {
+	if p.IsUnlimited() || strings.TrimSpace(orderBy) == "" {
+		return ""
+	}
+	first := p.Offset() + 1
+	return fmt.Sprintf("WHERE rn BETWEEN %d AND %d", first, first+int64(p.Limit())-1)
+}
+
 // HasPrevious returns true if there's a previous page.
 func (p *Paginator) HasPrevious() bool {
-	return p.Page > 1
+	return p.Page > p.firstPage()
 }
 
 // PreviousPage returns the previous page number.
-// Returns 1 if already on the first page.
+// Returns the first page if already on the first page.
 func (p *Paginator) PreviousPage() int {
-	if p.Page <= 1 {
-		return 1
+	if p.Page <= p.firstPage() {
+		return p.firstPage()
 	}
 	return p.Page - 1
 }
@@ -120,9 +334,15 @@ func (p *Paginator) NextPage() int {
 }
 
 // TotalPages calculates total pages from total count.
-// Returns 0 if total is 0 or negative.
+// Returns 0 if total is 0 or negative, and 1 for unlimited paginators.
 func (p *Paginator) TotalPages(total int64) int {
-	if total <= 0 || p.PageSize <= 0 {
+	if total <= 0 {
+		return 0
+	}
+	if p.IsUnlimited() {
+		return 1
+	}
+	if p.PageSize <= 0 {
 		return 0
 	}
 
@@ -140,24 +360,39 @@ func (p *Paginator) TotalPages(total int64) int {
 	return int(pages)
 }
 
+// TotalPagesCapped returns TotalPages limited to maxPages, and whether it was
+// truncated, so UIs can render "50,000+" instead of an enormous page count.
+// A maxPages <= 0 disables the cap.
+func (p *Paginator) TotalPagesCapped(total int64, maxPages int) (pages int, capped bool) {
+	pages = p.TotalPages(total)
+	if maxPages > 0 && pages > maxPages {
+		return maxPages, true
+	}
+	return pages, false
+}
+
 // HasNext returns true if there's a next page.
 func (p *Paginator) HasNext(total int64) bool {
-	return p.Page < p.TotalPages(total)
+	return p.pageIndex()+1 < int64(p.TotalPages(total))
 }
 
 // IsLastPage returns true if this is the last page.
 func (p *Paginator) IsLastPage(total int64) bool {
 	totalPages := p.TotalPages(total)
-	return totalPages > 0 && p.Page >= totalPages
+	return totalPages > 0 && p.pageIndex()+1 >= int64(totalPages)
 }
 
 // IsFirstPage returns true if this is the first page.
 func (p *Paginator) IsFirstPage() bool {
-	return p.Page == 1
+	return p.Page == p.firstPage()
 }
 
 // IsEmpty returns true if the current page would be empty given the total count.
+// Returns false if the total is unknown.
 func (p *Paginator) IsEmpty(total int64) bool {
+	if total < 0 {
+		return false
+	}
 	return p.Offset() >= total
 }
 
@@ -166,22 +401,77 @@ func (p *Paginator) Clone() *Paginator {
 	return &Paginator{
 		Page:     p.Page,
 		PageSize: p.PageSize,
+		opts:     p.opts,
+	}
+}
+
+// EffectiveResultCap returns the number of items to fetch for the current
+// page so that at most maxResults items are returned across all pages: the
+// page size, reduced on the page where the cap is reached, and 0 on pages
+// beyond it. A maxResults <= 0 means no cap, and Limit is returned, which is
+// NoLimit for unlimited paginators.
+func (p *Paginator) EffectiveResultCap(maxResults int) int {
+	if maxResults <= 0 {
+		return p.Limit()
+	}
+	remaining := max(int64(maxResults)-p.Offset(), 0)
+	if p.IsUnlimited() {
+		return int(remaining)
+	}
+	return int(min(int64(p.PageSize), remaining))
+}
+
+// Batches returns one independent paginator per page from the current page
+// through the last page for total, e.g. to distribute an offset walk across
+// a pool of workers. Returns nil if the total is unknown or the current page
+// is already beyond the last page.
+func (p *Paginator) Batches(total int64) []*Paginator {
+	remaining := int64(p.TotalPages(total)) - p.pageIndex()
+	if total < 0 || remaining <= 0 {
+		return nil
 	}
+
+	batches := make([]*Paginator, remaining)
+	for i := range batches {
+		batches[i] = p.Clone()
+		batches[i].Page = p.Page + i
+	}
+	return batches
 }
 
 // Clamp adjusts the page number to be within valid range based on total count.
+// The paginator is returned unchanged if the total is unknown.
 // Returns a new paginator instance.
 func (p *Paginator) Clamp(total int64) *Paginator {
-	maxPage := p.TotalPages(total)
-	if maxPage == 0 {
-		maxPage = 1
+	if total < 0 {
+		return p
 	}
+	maxPage := max(p.TotalPages(total), 1) - 1 + p.firstPage()
 	if p.Page > maxPage {
 		return p.WithPage(maxPage)
 	}
 	return p
 }
 
+// RedirectTarget returns the request URL with its page replaced by the last
+// valid page, and true, when the requested page is beyond the last page.
+// All other query parameters are preserved. Returns false if no redirect is
+// needed. Like CheckBounds, an empty or unknown total (total <= 0) never
+// redirects.
+func (p *Paginator) RedirectTarget(r *http.Request, total int64) (string, bool) {
+	if p.CheckBounds(total) == nil {
+		return "", false
+	}
+	clamped := p.Clamp(total)
+
+	target := *r.URL
+	q := target.Query()
+	q.Set("page", strconv.Itoa(clamped.Page))
+	q.Del("offset")
+	target.RawQuery = q.Encode()
+	return target.String(), true
+}
+
 // Items returns the range of item indices for this page [start, end).
 // Note: end is exclusive.
 func (p *Paginator) Items() (start, end int64) {
@@ -190,6 +480,25 @@ func (p *Paginator) Items() (start, end int64) {
 	return
 }
 
+// DisplayRange returns the 1-based, inclusive numbers of the first and last
+// items on this page for display, e.g. "Showing 41 to 60 of 95". The end is
+// clamped to total, so the last page shows the real count. Returns (0, 0) if
+// the page is empty. If the total is unknown (negative), the end assumes a
+// full page; for an unlimited paginator the end cannot be known and to is 0.
+func (p *Paginator) DisplayRange(total int64) (from, to int64) {
+	if total == 0 || p.IsEmpty(total) {
+		return 0, 0
+	}
+	start, end := p.Items()
+	if total >= 0 {
+		if p.IsUnlimited() {
+			end = total
+		}
+		end = min(end, total)
+	}
+	return start + 1, end
+}
+
 // QueryParams returns URL query parameters.
 func (p *Paginator) QueryParams() url.Values {
 	params := url.Values{}
@@ -203,52 +512,364 @@ func (p *Paginator) QueryString() string {
 	return p.QueryParams().Encode()
 }
 
+// QueryParamsOData returns OData-style $skip and $top query parameters.
+// $top is omitted for unlimited paginators.
+func (p *Paginator) QueryParamsOData() url.Values {
+	params := url.Values{}
+	params.Set("$skip", strconv.FormatInt(p.Offset(), 10))
+	if !p.IsUnlimited() {
+		params.Set("$top", strconv.Itoa(p.PageSize))
+	}
+	return params
+}
+
+// SelfURL returns the canonical URL of the current page: baseURL with the
+// paginator's QueryParams.
+func (p *Paginator) SelfURL(baseURL string) string {
+	return buildURL(baseURL, p.QueryParams())
+}
+
+// DefaultResetParams are the position parameters removed by ResetToFirst.
+var DefaultResetParams = []string{"page", "offset", "cursor", "after", "before"}
+
+// ResetToFirst returns a copy of the query values with the pagination
+// position removed, so the resulting URL points at the first page while
+// keeping filters, sort, and page size. By default DefaultResetParams are
+// removed; pass keys to remove a custom set instead.
+func ResetToFirst(current url.Values, keys ...string) url.Values {
+	if len(keys) == 0 {
+		keys = DefaultResetParams
+	}
+	reset := make(url.Values, len(current))
+	for k, v := range current {
+		reset[k] = slices.Clone(v)
+	}
+	for _, k := range keys {
+		reset.Del(k)
+	}
+	return reset
+}
+
 // FromRequest parses pagination from HTTP request.
 // Returns a paginator with validated default values.
 func FromRequest(r *http.Request) *Paginator {
-	return FromQuery(r.URL.Query())
+	return Options{}.FromRequest(r)
+}
+
+// FromRequest parses pagination from HTTP request like the package-level
+// FromRequest, returning a paginator with the options.
+func (o Options) FromRequest(r *http.Request) *Paginator {
+	return o.FromQuery(r.URL.Query())
+}
+
+// FromRequestForm parses pagination from a form-encoded request body
+// (application/x-www-form-urlencoded), falling back to the query string for
+// parameters not present in the body. Body values take precedence.
+// A body that was already consumed is treated as empty.
+// Returns an error if the form cannot be parsed.
+func FromRequestForm(r *http.Request) (*Paginator, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return FromQuery(r.Form), nil
+}
+
+// FromRequestAcceptParams parses pagination like FromRequest, falling back to
+// a page-size parameter on the Accept header media type
+// (e.g. "Accept: application/json; page-size=50") when the query string
+// carries no page size. Query parameters take precedence.
+func FromRequestAcceptParams(r *http.Request) *Paginator {
+	q := r.URL.Query()
+	p := FromQuery(q)
+
+	if q.Get("page_size") != "" || q.Get("limit") != "" || q.Get("per_page") != "" {
+		return p
+	}
+
+	for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if size, err := strconv.Atoi(params["page-size"]); err == nil && size > 0 {
+			return p.WithPageSize(size)
+		}
+	}
+
+	return p
+}
+
+// FromRequestPrefer parses pagination like FromRequest, falling back to the
+// max-items preference of the Prefer header (RFC 7240, e.g.
+// "Prefer: max-items=50") when the query string carries no page size. Query
+// parameters take precedence.
+// If the preference was honored, the returned string is the value to send in
+// the Preference-Applied response header (the page size actually used, after
+// clamping); otherwise it is empty.
+func FromRequestPrefer(r *http.Request) (*Paginator, string) {
+	q := r.URL.Query()
+	p := FromQuery(q)
+
+	if q.Get("page_size") != "" || q.Get("limit") != "" || q.Get("per_page") != "" {
+		return p, ""
+	}
+
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			pref, _, _ = strings.Cut(pref, ";")
+			name, value, ok := strings.Cut(pref, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "max-items") {
+				continue
+			}
+			size, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`))
+			if err != nil || size <= 0 {
+				continue
+			}
+			p = p.WithPageSize(size)
+			return p, "max-items=" + strconv.Itoa(p.PageSize)
+		}
+	}
+
+	return p, ""
+}
+
+// PaginationVaryHeaders returns the request headers this package reads to
+// paginate: Range (RangeFromRequest, DetectStrategy), Prefer
+// (FromRequestPrefer) and Accept (FromRequestAcceptParams). Handlers that
+// honor them should list them in the Vary response header, so caches do not
+// serve a cached full-collection response to a range request:
+//
+//	w.Header().Set("Vary", strings.Join(paginate.PaginationVaryHeaders(), ", "))
+//
+// A new slice is returned on every call.
+func PaginationVaryHeaders() []string {
+	return []string{"Range", "Prefer", "Accept"}
 }
 
 // FromQuery parses pagination from URL query values.
 // Invalid values are ignored and defaults are used instead.
+// limit and per_page are aliases of page_size: they always set the number of
+// items per page, never a cap on the total number of results. Clients that
+// need such a cap send max_results; see MaxResultsFromQuery.
+// A raw offset parameter (?offset=40&limit=20) is converted to the page
+// containing it, computed as offset/limit + 1; use OffsetLimitFromQuery when
+// offsets are not page-aligned.
 func FromQuery(q url.Values) *Paginator {
-	p := New()
+	return Options{}.FromQuery(q)
+}
+
+// FromQuery parses pagination from URL query values like the package-level
+// FromQuery, returning a paginator with the options. The page parameter is
+// read as 0-based if ZeroBased is set, and a page size of 0 is honored as
+// unlimited only if AllowUnlimited is set.
+func (o Options) FromQuery(q url.Values) *Paginator {
+	p := o.New()
 
 	if pageStr := q.Get("page"); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+		if page, err := strconv.Atoi(pageStr); err == nil && page >= p.firstPage() {
 			p = p.WithPage(page)
 		}
 	}
 
 	if sizeStr := q.Get("page_size"); sizeStr != "" {
-		if size, err := strconv.Atoi(sizeStr); err == nil && size > 0 {
+		if size, err := strconv.Atoi(sizeStr); err == nil && o.validQuerySize(size) {
 			p = p.WithPageSize(size)
 		}
 	}
 
 	// Support common alternatives
 	if sizeStr := q.Get("limit"); sizeStr != "" && q.Get("page_size") == "" {
-		if size, err := strconv.Atoi(sizeStr); err == nil && size > 0 {
+		if size, err := strconv.Atoi(sizeStr); err == nil && o.validQuerySize(size) {
 			p = p.WithPageSize(size)
 		}
 	}
 
 	if sizeStr := q.Get("per_page"); sizeStr != "" && q.Get("page_size") == "" {
-		if size, err := strconv.Atoi(sizeStr); err == nil && size > 0 {
+		if size, err := strconv.Atoi(sizeStr); err == nil && o.validQuerySize(size) {
 			p = p.WithPageSize(size)
 		}
 	}
 
+	// Raw offset, used only when no page is given
+	if offsetStr := q.Get("offset"); offsetStr != "" && q.Get("page") == "" && p.PageSize > 0 {
+		if offset, err := strconv.ParseInt(offsetStr, 10, 64); err == nil && offset >= 0 {
+			p = p.WithPage(int(offset/int64(p.PageSize)) + p.firstPage())
+		}
+	}
+
+	return p
+}
+
+// offsetParams lists the query parameters understood by FromQuery.
+var offsetParams = []string{"page", "page_size", "limit", "per_page", "offset"}
+
+// FromQueryStrict parses pagination from URL query values like FromQuery,
+// but rejects malformed input instead of falling back to defaults:
+//   - ErrDuplicateParam if a pagination parameter is repeated
+//   - ErrInvalidPage, ErrInvalidPageSize or ErrInvalidOffset if a parameter
+//     is not an integer within bounds
+func FromQueryStrict(q url.Values) (*Paginator, error) {
+	return Options{}.FromQueryStrict(q)
+}
+
+// FromQueryStrict parses pagination from URL query values like the
+// package-level FromQueryStrict, returning a paginator with the options.
+func (o Options) FromQueryStrict(q url.Values) (*Paginator, error) {
+	for _, name := range offsetParams {
+		if len(q[name]) > 1 {
+			return nil, fmt.Errorf("%w: %s given %d times", ErrDuplicateParam, name, len(q[name]))
+		}
+	}
+	if err := checkOffsetParams(q, o); err != nil {
+		return nil, err
+	}
+	p := o.FromQuery(q)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// validQuerySize returns true if a page size from the query string should be
+// applied: positive sizes always, 0 (unlimited) only if AllowUnlimited is set.
+func (o Options) validQuerySize(size int) bool {
+	return size > 0 || (size == 0 && o.AllowUnlimited)
+}
+
+// FromQueryOData parses OData-style $top and $skip query parameters.
+// $top sets the page size, clamped like WithPageSize. Since a Paginator
+// addresses whole pages, $skip is mapped to the page containing it,
+// computed as $skip/$top + 1; a $skip that is not a multiple of $top is
+// rounded down to the start of that page.
+// Invalid values are ignored and defaults are used instead.
+func FromQueryOData(q url.Values) *Paginator {
+	p := New()
+
+	if topStr := q.Get("$top"); topStr != "" {
+		if top, err := strconv.Atoi(topStr); err == nil && top >= 0 {
+			p = p.WithPageSize(top)
+		}
+	}
+
+	if skipStr := q.Get("$skip"); skipStr != "" && p.PageSize > 0 {
+		if skip, err := strconv.ParseInt(skipStr, 10, 64); err == nil && skip >= 0 {
+			p = p.WithPage(int(skip/int64(p.PageSize)) + p.firstPage())
+		}
+	}
+
 	return p
 }
 
+// MaxResultsFromQuery parses the max_results query parameter, a hard cap on
+// the total number of results across all pages, distinct from the per-page
+// limit. Returns 0 (no cap) if it is absent or not a positive integer.
+// Apply it with Paginator.EffectiveResultCap.
+func MaxResultsFromQuery(q url.Values) int {
+	if n, err := strconv.Atoi(q.Get("max_results")); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// OffsetLimitFromQuery parses raw offset and limit query parameters for
+// callers that query by exact offset rather than by page.
+// Invalid or negative offsets are treated as 0; the limit is clamped like
+// WithPageSize and defaults to DefaultPageSize.
+func OffsetLimitFromQuery(q url.Values) (offset int64, limit int) {
+	if o, err := strconv.ParseInt(q.Get("offset"), 10, 64); err == nil && o > 0 {
+		offset = o
+	}
+
+	limit = DefaultPageSize
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil {
+		limit = New().WithPageSize(l).PageSize
+	}
+
+	return offset, limit
+}
+
+// MaxPageRange limits how many pages ParsePageRange and PageRangeOffsetLimit
+// accept in one bulk request, so the query limit stays bounded by
+// MaxPageRange*MaxPageSize. Zero means unlimited.
+// It should only be set during program initialization.
+var MaxPageRange = 10
+
+// ParsePageRange parses a page range such as pages=2-4 from URL query values,
+// for bulk fetching several consecutive pages at once. A single page
+// (pages=3) yields from == to. Page numbers are 1-based.
+// Returns ErrInvalidPage if the parameter is missing or malformed, a page is
+// below 1, from is after to, or the range spans more than MaxPageRange pages.
+func ParsePageRange(q url.Values) (from, to int, err error) {
+	s := q.Get("pages")
+	if s == "" {
+		return 0, 0, fmt.Errorf("%w: missing pages parameter", ErrInvalidPage)
+	}
+
+	fromStr, toStr, found := strings.Cut(s, "-")
+	if !found {
+		toStr = fromStr
+	}
+	from, errFrom := strconv.Atoi(fromStr)
+	to, errTo := strconv.Atoi(toStr)
+	if errFrom != nil || errTo != nil {
+		return 0, 0, fmt.Errorf("%w: malformed page range %q", ErrInvalidPage, s)
+	}
+	if err := checkPageRange(from, to); err != nil {
+		return 0, 0, err
+	}
+
+	return from, to, nil
+}
+
+// checkPageRange returns ErrInvalidPage if from is below page 1 or
+// after to, or the range spans more than MaxPageRange pages.
+func checkPageRange(from, to int) error {
+	if from < DefaultPage || from > to {
+		return fmt.Errorf("%w: invalid page range %d-%d", ErrInvalidPage, from, to)
+	}
+	if MaxPageRange > 0 && int64(to)-int64(from) >= int64(MaxPageRange) {
+		return fmt.Errorf("%w: page range %d-%d spans more than %d pages",
+			ErrInvalidPage, from, to, MaxPageRange)
+	}
+	return nil
+}
+
+// PageRangeOffsetLimit returns the offset and limit spanning pages from
+// through to (inclusive) of the given page size, for fetching them in a
+// single query. Page numbers are 1-based.
+// Returns ErrInvalidPageSize if pageSize is outside [MinPageSize, MaxPageSize],
+// or ErrInvalidPage if the range is invalid, spans more than MaxPageRange
+// pages, or its offset or limit overflows.
+func PageRangeOffsetLimit(from, to, pageSize int) (offset int64, limit int, err error) {
+	if pageSize < MinPageSize || pageSize > MaxPageSize {
+		return 0, 0, fmt.Errorf("%w: got %d, allowed range [%d, %d]",
+			ErrInvalidPageSize, pageSize, MinPageSize, MaxPageSize)
+	}
+	if err := checkPageRange(from, to); err != nil {
+		return 0, 0, err
+	}
+
+	p := &Paginator{Page: from, PageSize: pageSize}
+	pages := int64(to) - int64(from) + 1
+	if p.OffsetOverflows() || pages > int64(math.MaxInt/pageSize) {
+		return 0, 0, fmt.Errorf("%w: page range %d-%d overflows", ErrInvalidPage, from, to)
+	}
+	return p.Offset(), int(pages) * pageSize, nil
+}
+
 // FromMap parses pagination from a map (useful for JSON APIs).
 // Invalid values are ignored and defaults are used instead.
 func FromMap(m map[string]any) *Paginator {
-	p := New()
+	return Options{}.FromMap(m)
+}
+
+// FromMap parses pagination from a map like the package-level FromMap,
+// returning a paginator with the options.
+func (o Options) FromMap(m map[string]any) *Paginator {
+	p := o.New()
 
 	if v, ok := m["page"]; ok {
-		if page := extractInt(v); page > 0 {
+		if page := extractInt(v); page >= p.firstPage() {
 			p = p.WithPage(page)
 		}
 	}
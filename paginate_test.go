@@ -1,9 +1,14 @@
 package paginate
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"math"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -63,6 +68,203 @@ func TestWithPageSize(t *testing.T) {
 	}
 }
 
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        Paginator
+		expectedPage int
+		expectedSize int
+	}{
+		{"Valid", Paginator{Page: 3, PageSize: 50}, 3, 50},
+		{"Zero values", Paginator{}, DefaultPage, DefaultPageSize},
+		{"Negative values", Paginator{Page: -2, PageSize: -10}, DefaultPage, DefaultPageSize},
+		{"Oversized page size", Paginator{Page: 2, PageSize: 99999}, 2, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.input.WithDefaults()
+			if p.Page != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, p.Page)
+			}
+			if p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page size %d, got %d", tt.expectedSize, p.PageSize)
+			}
+			if err := p.Validate(); err != nil {
+				t.Errorf("Expected valid paginator, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPaginatorUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedPage int
+		expectedSize int
+	}{
+		{"Valid", `{"page":3,"page_size":50}`, 3, 50},
+		{"Missing fields", `{}`, DefaultPage, DefaultPageSize},
+		{"Zero page", `{"page":0,"page_size":10}`, DefaultPage, 10},
+		{"Oversized page size", `{"page":2,"page_size":99999}`, 2, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Paginator
+			if err := json.Unmarshal([]byte(tt.input), &p); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if p.Page != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, p.Page)
+			}
+			if p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page size %d, got %d", tt.expectedSize, p.PageSize)
+			}
+		})
+	}
+
+	var p Paginator
+	if err := json.Unmarshal([]byte(`{"page":"x"}`), &p); err == nil {
+		t.Error("Expected error for invalid JSON types")
+	}
+}
+
+func TestAllowUnlimited(t *testing.T) {
+	// Disabled by default: page_size=0 falls back to the default
+	if p := New().WithPageSize(0); p.PageSize != DefaultPageSize || p.IsUnlimited() {
+		t.Errorf("Expected default page size without AllowUnlimited, got %d", p.PageSize)
+	}
+	// A zero alias is ignored rather than resetting an explicit size
+	if p := FromQuery(url.Values{"limit": {"50"}, "per_page": {"0"}}); p.PageSize != 50 {
+		t.Errorf("Expected page size 50 without AllowUnlimited, got %d", p.PageSize)
+	}
+
+	unlimited := Options{AllowUnlimited: true}
+	p := unlimited.FromQuery(url.Values{"page_size": {"0"}})
+	if !p.IsUnlimited() {
+		t.Fatal("Expected unlimited paginator")
+	}
+	if p.Limit() != NoLimit {
+		t.Errorf("Expected limit %d, got %d", NoLimit, p.Limit())
+	}
+	if p.Offset() != 0 {
+		t.Errorf("Expected offset 0, got %d", p.Offset())
+	}
+	if clause := p.SQLClause(); clause != "" {
+		t.Errorf("Expected empty SQL clause, got '%s'", clause)
+	}
+	if clause := p.SQLClauseMySQL(); clause != "" {
+		t.Errorf("Expected empty MySQL clause, got '%s'", clause)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if pages := p.TotalPages(500); pages != 1 {
+		t.Errorf("Expected 1 total page, got %d", pages)
+	}
+	if p.HasNext(500) {
+		t.Error("Expected no next page")
+	}
+	if NewPage([]int{1, 2, 3}, UnknownTotal, p).HasNext {
+		t.Error("Expected no next page for unlimited page with unknown total")
+	}
+
+	// Missing page_size still means default
+	decoded := unlimited.New()
+	if err := json.Unmarshal([]byte(`{"page":1}`), decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.PageSize != DefaultPageSize {
+		t.Errorf("Expected default page size, got %d", decoded.PageSize)
+	}
+	if err := json.Unmarshal([]byte(`{"page":1,"page_size":0}`), decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.IsUnlimited() {
+		t.Error("Expected explicit page_size 0 to decode as unlimited")
+	}
+
+	// Other paginators in the same program are unaffected
+	if FromQuery(url.Values{"page_size": {"0"}}).IsUnlimited() {
+		t.Error("Expected default options to ignore page_size 0")
+	}
+	if limited := p.WithOptions(Options{}); limited.IsUnlimited() || limited.PageSize != DefaultPageSize {
+		t.Errorf("Expected default page size after dropping AllowUnlimited, got %d", limited.PageSize)
+	}
+}
+
+func TestZeroBased(t *testing.T) {
+	zeroBased := Options{ZeroBased: true}
+
+	p := zeroBased.New()
+	if p.Page != 0 {
+		t.Errorf("Expected first page 0, got %d", p.Page)
+	}
+	if !p.IsFirstPage() || p.HasPrevious() {
+		t.Error("Expected page 0 to be the first page")
+	}
+
+	p = zeroBased.FromQuery(url.Values{"page": {"0"}, "page_size": {"10"}})
+	if p.Page != 0 || p.Offset() != 0 {
+		t.Errorf("Expected page 0 with offset 0, got page %d offset %d", p.Page, p.Offset())
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	p = p.WithPage(2)
+	if p.Offset() != 20 {
+		t.Errorf("Expected offset 20, got %d", p.Offset())
+	}
+	if p.WithPage(-1).Page != 0 {
+		t.Error("Expected negative page to clamp to 0")
+	}
+
+	// 25 items in pages of 10: pages 0, 1, 2
+	if !p.IsLastPage(25) || p.HasNext(25) {
+		t.Error("Expected page 2 to be the last page")
+	}
+	if p.WithPage(1).HasNext(25) != true {
+		t.Error("Expected page 1 to have a next page")
+	}
+	if clamped := p.WithPage(9).Clamp(25); clamped.Page != 2 {
+		t.Errorf("Expected clamp to page 2, got %d", clamped.Page)
+	}
+	if err := p.WithPage(3).CheckBounds(25); !errors.Is(err, ErrPageOutOfRange) {
+		t.Errorf("Expected ErrPageOutOfRange, got %v", err)
+	}
+
+	header := BuildLinkHeader("http://example.com", p.WithPage(1), 25)
+	if !contains(header.First, "page=0") || !contains(header.Last, "page=2") {
+		t.Errorf("Unexpected links: %+v", header)
+	}
+	if r := NewRange(20, 29).ToPaginator().WithOptions(zeroBased); r.Page != 2 {
+		t.Errorf("Expected range to map to page 2, got %d", r.Page)
+	}
+
+	// Paginators without the option are unaffected
+	if New().Page != 1 || FromQuery(url.Values{"page": {"0"}}).Page != 1 {
+		t.Error("Expected default paginators to stay 1-based")
+	}
+
+	decoded := zeroBased.New()
+	if err := json.Unmarshal([]byte(`{"page_size":10}`), decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Page != 0 || !decoded.Options().ZeroBased {
+		t.Errorf("Expected decoding to keep the options, got %+v", decoded)
+	}
+	if page := NewPage([]int{1}, 25, p.WithPage(0)); !page.IsFirst() {
+		t.Error("Expected page 0 to be the first page of the response")
+	}
+
+	if oneBased := p.WithOptions(Options{}); oneBased.Page != 3 || oneBased.Offset() != p.Offset() {
+		t.Errorf("Expected page 3 at the same offset, got page %d offset %d", oneBased.Page, oneBased.Offset())
+	}
+}
+
 func TestOffset(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -96,6 +298,40 @@ func TestOffsetOverflow(t *testing.T) {
 	}
 }
 
+func TestOffsetOverflows(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("int64 offsets cannot overflow with 32-bit ints")
+	}
+
+	tests := []struct {
+		name     string
+		page     int
+		pageSize int
+		expected bool
+	}{
+		{"Small", 3, 20, false},
+		{"Large but safe", math.MaxInt32 / 2, math.MaxInt32 / 2, false},
+		{"Exact limit", math.MaxInt/1000 + 1, 1000, false},
+		{"Just over", math.MaxInt/1000 + 2, 1000, true},
+		{"Max page", math.MaxInt, 2, true},
+		{"First page", 1, math.MaxInt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Paginator{Page: tt.page, PageSize: tt.pageSize}
+			if got := p.OffsetOverflows(); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	p := &Paginator{Page: math.MaxInt, PageSize: 20}
+	if err := p.Validate(); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("Expected ErrInvalidPage from Validate, got %v", err)
+	}
+}
+
 func TestTotalPages(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -121,6 +357,121 @@ func TestTotalPages(t *testing.T) {
 	}
 }
 
+func TestTotalPagesCapped(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int64
+		maxPages int
+		pages    int
+		capped   bool
+	}{
+		{"Below cap", 100, 10, 5, false},
+		{"At cap", 200, 10, 10, false},
+		{"Above cap", 1_000_000_000, 50_000, 50_000, true},
+		{"No cap", 1_000_000_000, 0, 50_000_000, false},
+		{"Zero total", 0, 10, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, capped := NewWithSize(20).TotalPagesCapped(tt.total, tt.maxPages)
+			if pages != tt.pages || capped != tt.capped {
+				t.Errorf("Expected (%d, %v), got (%d, %v)", tt.pages, tt.capped, pages, capped)
+			}
+		})
+	}
+}
+
+func TestEffectiveResultCap(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int
+		maxResults int
+		expected   int
+	}{
+		{"No cap", 3, 0, 20},
+		{"Below cap", 1, 50, 20},
+		{"Reaches cap", 3, 50, 10},
+		{"Beyond cap", 4, 50, 0},
+		{"Exact boundary", 3, 40, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewWithSize(20).WithPage(tt.page)
+			if got := p.EffectiveResultCap(tt.maxResults); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	unlimited := Options{AllowUnlimited: true}.New().WithPageSize(0)
+	if got := unlimited.EffectiveResultCap(0); got != NoLimit {
+		t.Errorf("Expected NoLimit without a cap for unlimited paginator, got %d", got)
+	}
+	if got := unlimited.EffectiveResultCap(50); got != 50 {
+		t.Errorf("Expected 50 for capped unlimited paginator, got %d", got)
+	}
+}
+
+func TestMaxResultsFromQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"max_results=100", 100},
+		{"limit=10", 0},
+		{"max_results=-5", 0},
+		{"max_results=abc", 0},
+	}
+
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+		if got := MaxResultsFromQuery(q); got != tt.expected {
+			t.Errorf("%s: expected %d, got %d", tt.query, tt.expected, got)
+		}
+	}
+
+	q, _ := url.ParseQuery("limit=10&max_results=25")
+	if p := FromQuery(q); p.PageSize != 10 {
+		t.Errorf("Expected limit to remain the page size, got %d", p.PageSize)
+	}
+}
+
+func TestBatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		total    int64
+		expected []int
+	}{
+		{"All pages", 1, 45, []int{1, 2, 3, 4, 5}},
+		{"From current page", 3, 45, []int{3, 4, 5}},
+		{"Last page", 5, 45, []int{5}},
+		{"Beyond last page", 6, 45, nil},
+		{"Zero total", 1, 0, nil},
+		{"Unknown total", 1, UnknownTotal, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewWithSize(10).WithPage(tt.page)
+			batches := p.Batches(tt.total)
+			if len(batches) != len(tt.expected) {
+				t.Fatalf("Expected %d batches, got %d", len(tt.expected), len(batches))
+			}
+			for i, b := range batches {
+				if b.Page != tt.expected[i] || b.PageSize != 10 {
+					t.Errorf("Batch %d: expected page %d size 10, got %+v", i, tt.expected[i], b)
+				}
+				if b == p {
+					t.Error("Batches should be independent of the receiver")
+				}
+			}
+		})
+	}
+}
+
 func TestHasNext(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -192,6 +543,38 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestCheckBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		page      int
+		total     int64
+		wantError bool
+	}{
+		{"First page", 1, 50, false},
+		{"Last page", 3, 50, false},
+		{"Beyond last page", 5, 50, true},
+		{"Empty collection", 5, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFromValues(tt.page, 20)
+			err := p.CheckBounds(tt.total)
+			if (err != nil) != tt.wantError {
+				t.Errorf("Expected error=%v, got error=%v", tt.wantError, err)
+			}
+			if tt.wantError {
+				if !errors.Is(err, ErrPageOutOfRange) {
+					t.Errorf("Expected ErrPageOutOfRange, got %v", err)
+				}
+				if !errors.Is(err, ErrInvalidPage) {
+					t.Errorf("Expected error to wrap ErrInvalidPage, got %v", err)
+				}
+			}
+		})
+	}
+}
+
 func TestClone(t *testing.T) {
 	p1 := NewFromValues(5, 50)
 	p2 := p1.Clone()
@@ -262,6 +645,311 @@ func TestFromRequest(t *testing.T) {
 	}
 }
 
+func TestFromRequestForm(t *testing.T) {
+	newFormRequest := func(target, body string) *http.Request {
+		req, _ := http.NewRequest("POST", target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		expectedPage int
+		expectedSize int
+	}{
+		{"Body only", "http://example.com/search", "page=3&page_size=50", 3, 50},
+		{"Query fallback", "http://example.com/search?page=2", "page_size=10", 2, 10},
+		{"Body takes precedence", "http://example.com/search?page=2", "page=4", 4, DefaultPageSize},
+		{"Empty body", "http://example.com/search?page=5", "", 5, DefaultPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := FromRequestForm(newFormRequest(tt.url, tt.body))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if p.Page != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, p.Page)
+			}
+			if p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page size %d, got %d", tt.expectedSize, p.PageSize)
+			}
+		})
+	}
+
+	// Body already consumed by an earlier reader
+	req := newFormRequest("http://example.com/search?page=2", "page=9")
+	_, _ = io.ReadAll(req.Body)
+	p, err := FromRequestForm(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Page != 2 {
+		t.Errorf("Expected page 2 from query, got %d", p.Page)
+	}
+
+	// Malformed body
+	if _, err := FromRequestForm(newFormRequest("http://example.com/search", "page=%zz")); err == nil {
+		t.Error("Expected error for malformed form body")
+	}
+}
+
+func TestFromRequestAcceptParams(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		accept       string
+		expectedPage int
+		expectedSize int
+	}{
+		{"No accept", "http://example.com?page=2", "", 2, DefaultPageSize},
+		{"Accept page size", "http://example.com?page=2", "application/json; page-size=50", 2, 50},
+		{"Multiple media ranges", "http://example.com", "text/html, application/json; page-size=30", 1, 30},
+		{"Query takes precedence", "http://example.com?page_size=10", "application/json; page-size=50", 1, 10},
+		{"Limit takes precedence", "http://example.com?limit=15", "application/json; page-size=50", 1, 15},
+		{"Invalid page size", "http://example.com", "application/json; page-size=abc", 1, DefaultPageSize},
+		{"Exceeds max", "http://example.com", "application/json; page-size=5000", 1, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			p := FromRequestAcceptParams(req)
+
+			if p.Page != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, p.Page)
+			}
+			if p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page size %d, got %d", tt.expectedSize, p.PageSize)
+			}
+		})
+	}
+}
+
+func TestFromRequestPrefer(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		prefer       []string
+		expectedSize int
+		applied      string
+	}{
+		{"No prefer", "http://example.com", nil, DefaultPageSize, ""},
+		{"Max items", "http://example.com?page=2", []string{"max-items=50"}, 50, "max-items=50"},
+		{"Among preferences", "http://example.com", []string{"return=minimal, max-items=30"}, 30, "max-items=30"},
+		{"Multiple headers", "http://example.com", []string{"return=minimal", `Max-Items="25"`}, 25, "max-items=25"},
+		{"Query takes precedence", "http://example.com?page_size=10", []string{"max-items=50"}, 10, ""},
+		{"Invalid value", "http://example.com", []string{"max-items=abc"}, DefaultPageSize, ""},
+		{"Clamped to max", "http://example.com", []string{"max-items=5000"}, MaxPageSize, "max-items=1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			for _, v := range tt.prefer {
+				req.Header.Add("Prefer", v)
+			}
+			p, applied := FromRequestPrefer(req)
+
+			if p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page size %d, got %d", tt.expectedSize, p.PageSize)
+			}
+			if applied != tt.applied {
+				t.Errorf("Expected Preference-Applied %q, got %q", tt.applied, applied)
+			}
+		})
+	}
+}
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		from, to  int
+		wantError bool
+	}{
+		{"Range", "pages=2-4", 2, 4, false},
+		{"Single page", "pages=3", 3, 3, false},
+		{"Same page", "pages=5-5", 5, 5, false},
+		{"Missing", "", 0, 0, true},
+		{"Reversed", "pages=4-2", 0, 0, true},
+		{"Zero page", "pages=0-2", 0, 0, true},
+		{"Malformed", "pages=a-b", 0, 0, true},
+		{"Open ended", "pages=2-", 0, 0, true},
+		{"Too many pages", "pages=1-11", 0, 0, true},
+		{"Huge span", "pages=1-9223372036854775807", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			from, to, err := ParsePageRange(q)
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidPage) {
+					t.Errorf("Expected ErrInvalidPage, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if from != tt.from || to != tt.to {
+				t.Errorf("Expected %d-%d, got %d-%d", tt.from, tt.to, from, to)
+			}
+		})
+	}
+}
+
+func TestPageRangeOffsetLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		from, to, size int
+		offset         int64
+		limit          int
+		wantError      error
+	}{
+		{"Several pages", 2, 4, 20, 20, 60, nil},
+		{"Single page", 1, 1, 50, 0, 50, nil},
+		{"Too many pages", 1, 20, 20, 0, 0, ErrInvalidPage},
+		{"Huge span", 1, math.MaxInt, 20, 0, 0, ErrInvalidPage},
+		{"Reversed", 4, 2, 20, 0, 0, ErrInvalidPage},
+		{"Negative page size", 1, 2, -20, 0, 0, ErrInvalidPageSize},
+		{"Page size above max", 1, 2, MaxPageSize + 1, 0, 0, ErrInvalidPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, limit, err := PageRangeOffsetLimit(tt.from, tt.to, tt.size)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if offset != tt.offset || limit != tt.limit {
+				t.Errorf("Expected offset %d limit %d, got offset %d limit %d", tt.offset, tt.limit, offset, limit)
+			}
+		})
+	}
+}
+
+func TestMaxPageRangeUnlimited(t *testing.T) {
+	MaxPageRange = 0
+	defer func() { MaxPageRange = 10 }()
+
+	q, _ := url.ParseQuery("pages=2-50")
+	if from, to, err := ParsePageRange(q); err != nil || from != 2 || to != 50 {
+		t.Errorf("Expected 2-50 with MaxPageRange 0, got %d-%d (%v)", from, to, err)
+	}
+	if _, limit, err := PageRangeOffsetLimit(1, 20, 20); err != nil || limit != 400 {
+		t.Errorf("Expected limit 400 with MaxPageRange 0, got %d (%v)", limit, err)
+	}
+	if _, _, err := PageRangeOffsetLimit(1, math.MaxInt, 20); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("Expected overflow to be rejected, got %v", err)
+	}
+}
+
+func TestFromQueryOData(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedPage int
+		expectedSize int
+	}{
+		{"No params", "", 1, DefaultPageSize},
+		{"Top only", "$top=10", 1, 10},
+		{"Aligned skip", "$skip=40&$top=20", 3, 20},
+		{"Unaligned skip", "$skip=45&$top=20", 3, 20},
+		{"Skip with default top", "$skip=40", 3, DefaultPageSize},
+		{"Top exceeds max", "$top=5000", 1, MaxPageSize},
+		{"Invalid values", "$skip=abc&$top=-1", 1, DefaultPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			p := FromQueryOData(q)
+			if p.Page != tt.expectedPage || p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page %d size %d, got page %d size %d",
+					tt.expectedPage, tt.expectedSize, p.Page, p.PageSize)
+			}
+		})
+	}
+}
+
+func TestQueryParamsOData(t *testing.T) {
+	params := NewFromValues(3, 20).QueryParamsOData()
+	if params.Get("$skip") != "40" || params.Get("$top") != "20" {
+		t.Errorf("Unexpected params: %v", params)
+	}
+
+	p := FromQueryOData(params)
+	if p.Page != 3 || p.PageSize != 20 {
+		t.Errorf("Expected round trip to page 3 size 20, got %+v", p)
+	}
+}
+
+func TestFromQueryOffset(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedPage int
+		expectedSize int
+	}{
+		{"Aligned offset", "offset=40&limit=20", 3, 20},
+		{"Unaligned offset", "offset=45&limit=20", 3, 20},
+		{"Zero offset", "offset=0&limit=20", 1, 20},
+		{"Default limit", "offset=40", 3, DefaultPageSize},
+		{"Page takes precedence", "page=5&offset=40&limit=20", 5, 20},
+		{"Negative offset", "offset=-10&limit=20", 1, 20},
+		{"Invalid offset", "offset=abc&limit=20", 1, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			p := FromQuery(q)
+			if p.Page != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, p.Page)
+			}
+			if p.PageSize != tt.expectedSize {
+				t.Errorf("Expected page size %d, got %d", tt.expectedSize, p.PageSize)
+			}
+		})
+	}
+}
+
+func TestOffsetLimitFromQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedOffset int64
+		expectedLimit  int
+	}{
+		{"Both", "offset=45&limit=20", 45, 20},
+		{"Defaults", "", 0, DefaultPageSize},
+		{"Negative offset", "offset=-5", 0, DefaultPageSize},
+		{"Oversized limit", "limit=5000", 0, MaxPageSize},
+		{"Invalid limit", "offset=10&limit=abc", 10, DefaultPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			offset, limit := OffsetLimitFromQuery(q)
+			if offset != tt.expectedOffset {
+				t.Errorf("Expected offset %d, got %d", tt.expectedOffset, offset)
+			}
+			if limit != tt.expectedLimit {
+				t.Errorf("Expected limit %d, got %d", tt.expectedLimit, limit)
+			}
+		})
+	}
+}
+
 func TestFromMap(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -308,6 +996,139 @@ func TestSQLClauseMySQL(t *testing.T) {
 	}
 }
 
+func TestPaginationVaryHeaders(t *testing.T) {
+	headers := PaginationVaryHeaders()
+	if got := strings.Join(headers, ", "); got != "Range, Prefer, Accept" {
+		t.Errorf("Expected \"Range, Prefer, Accept\", got %q", got)
+	}
+
+	headers[0] = "X-Modified"
+	if PaginationVaryHeaders()[0] != "Range" {
+		t.Error("Expected a new slice on every call")
+	}
+}
+
+func TestFromQueryStrict(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		page  int
+		size  int
+		err   error
+	}{
+		{"Valid", "page=3&page_size=50", 3, 50, nil},
+		{"Defaults", "", 1, DefaultPageSize, nil},
+		{"Duplicate page", "page=2&page=3", 0, 0, ErrDuplicateParam},
+		{"Duplicate alias", "limit=10&limit=20", 0, 0, ErrDuplicateParam},
+		{"Invalid page", "page=abc", 0, 0, ErrInvalidPage},
+		{"Oversized page", "page_size=1001", 0, 0, ErrInvalidPageSize},
+		{"Negative offset", "offset=-1", 0, 0, ErrInvalidOffset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			p, err := FromQueryStrict(q)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if p.Page != tt.page || p.PageSize != tt.size {
+				t.Errorf("Expected page %d size %d, got %d %d", tt.page, tt.size, p.Page, p.PageSize)
+			}
+		})
+	}
+}
+
+func TestValidateAllowedSizes(t *testing.T) {
+	allowed := []int{10, 25, 50, 100}
+
+	tests := []struct {
+		name    string
+		size    int
+		sizes   []int
+		wantErr bool
+	}{
+		{"Allowed", 25, allowed, false},
+		{"Disallowed", 30, allowed, true},
+		{"No restriction", 30, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewFromValues(1, tt.size).ValidateAllowedSizes(tt.sizes...)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDisallowedPageSize) || !errors.Is(err, ErrInvalidPageSize) {
+				t.Errorf("Expected ErrDisallowedPageSize, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "[10 25 50 100]") {
+				t.Errorf("Expected allowed sizes in error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSnapPageSize(t *testing.T) {
+	allowed := []int{100, 10, 50, 25}
+
+	tests := []struct {
+		name     string
+		size     int
+		sizes    []int
+		expected int
+	}{
+		{"Exact", 50, allowed, 50},
+		{"Nearest below", 30, allowed, 25},
+		{"Nearest above", 45, allowed, 50},
+		{"Tie rounds down", 75, allowed, 50},
+		{"Below smallest", 1, allowed, 10},
+		{"Above largest", 100, []int{10, 25}, 25},
+		{"No sizes", 30, nil, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFromValues(2, tt.size)
+			snapped := p.SnapPageSize(tt.sizes...)
+			if snapped.PageSize != tt.expected {
+				t.Errorf("Expected page size %d, got %d", tt.expected, snapped.PageSize)
+			}
+			if snapped.Page != 2 || p.PageSize != tt.size {
+				t.Error("Expected page to be kept and original to be unchanged")
+			}
+		})
+	}
+}
+
+func TestSQLRowNumberClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		orderBy  string
+		expected string
+	}{
+		{"First page", 1, "id", "WHERE rn BETWEEN 1 AND 20"},
+		{"Third page", 3, "created_at DESC, id", "WHERE rn BETWEEN 41 AND 60"},
+		{"Missing order", 3, " ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFromValues(tt.page, 20)
+			if clause := p.SQLRowNumberClause(tt.orderBy); clause != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, clause)
+			}
+		})
+	}
+}
+
 func TestQueryParams(t *testing.T) {
 	p := NewFromValues(5, 50)
 	params := p.QueryParams()
@@ -320,6 +1141,37 @@ func TestQueryParams(t *testing.T) {
 	}
 }
 
+func TestResetToFirst(t *testing.T) {
+	current := url.Values{
+		"page":      {"5"},
+		"page_size": {"50"},
+		"after":     {"abc"},
+		"status":    {"active", "pending"},
+		"sort":      {"-created_at"},
+	}
+
+	reset := ResetToFirst(current)
+	for _, k := range []string{"page", "after"} {
+		if reset.Has(k) {
+			t.Errorf("Expected %s to be removed", k)
+		}
+	}
+	if reset.Get("page_size") != "50" || reset.Get("sort") != "-created_at" {
+		t.Errorf("Expected page size and sort to be kept, got %v", reset)
+	}
+	if len(reset["status"]) != 2 {
+		t.Errorf("Expected both status values to be kept, got %v", reset["status"])
+	}
+	if !current.Has("page") {
+		t.Error("ResetToFirst should not modify the input")
+	}
+
+	custom := ResetToFirst(current, "page", "page_size")
+	if custom.Has("page_size") || !custom.Has("after") {
+		t.Errorf("Expected custom keys to be removed, got %v", custom)
+	}
+}
+
 func TestClamp(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -330,6 +1182,7 @@ func TestClamp(t *testing.T) {
 		{"Within range", 5, 1000, 5},
 		{"Beyond total", 100, 50, 3}, // 50 items / 20 per page = 3 pages
 		{"Zero total", 5, 0, 1},
+		{"Unknown total", 5, UnknownTotal, 5},
 	}
 
 	for _, tt := range tests {
@@ -342,6 +1195,36 @@ func TestClamp(t *testing.T) {
 	}
 }
 
+func TestRedirectTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		total      int64
+		expected   string
+		shouldMove bool
+	}{
+		{"Beyond last page", "/items?page=9999&page_size=20&status=active", 100, "/items?page=5&page_size=20&status=active", true},
+		{"Within range", "/items?page=3&page_size=20", 100, "", false},
+		{"Last page", "/items?page=5&page_size=20", 100, "", false},
+		{"Empty collection", "/items?page=3", 0, "", false},
+		{"Unknown total", "/items?page=9999", UnknownTotal, "", false},
+		{"Offset replaced", "/items?offset=5000&limit=20", 100, "/items?limit=20&page=5", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			target, ok := FromRequest(req).RedirectTarget(req, tt.total)
+			if ok != tt.shouldMove {
+				t.Fatalf("Expected redirect=%v, got %v", tt.shouldMove, ok)
+			}
+			if target != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, target)
+			}
+		})
+	}
+}
+
 func TestIsEmpty(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -354,6 +1237,7 @@ func TestIsEmpty(t *testing.T) {
 		{"Last page not empty", 5, 20, 100, false},
 		{"Beyond total", 10, 20, 50, true},
 		{"Exactly at total", 6, 20, 100, true},
+		{"Unknown total", 6, 20, UnknownTotal, false},
 	}
 
 	for _, tt := range tests {
@@ -378,6 +1262,38 @@ func TestItems(t *testing.T) {
 	}
 }
 
+func TestDisplayRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		total    int64
+		from, to int64
+	}{
+		{"Full page", 3, 95, 41, 60},
+		{"Last page", 5, 95, 81, 95},
+		{"Beyond last page", 6, 95, 0, 0},
+		{"Empty result", 1, 0, 0, 0},
+		{"Unknown total", 2, UnknownTotal, 21, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := NewFromValues(tt.page, 20).DisplayRange(tt.total)
+			if from != tt.from || to != tt.to {
+				t.Errorf("Expected %d to %d, got %d to %d", tt.from, tt.to, from, to)
+			}
+		})
+	}
+
+	unlimited := Options{AllowUnlimited: true}.New().WithPageSize(0)
+	if from, to := unlimited.DisplayRange(95); from != 1 || to != 95 {
+		t.Errorf("Expected 1 to 95 for unlimited paginator, got %d to %d", from, to)
+	}
+	if from, to := unlimited.DisplayRange(UnknownTotal); from != 1 || to != 0 {
+		t.Errorf("Expected 1 to 0 for unlimited paginator with unknown total, got %d to %d", from, to)
+	}
+}
+
 func TestIsFirstPage(t *testing.T) {
 	if !New().IsFirstPage() {
 		t.Error("Default paginator should be on first page")
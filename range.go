@@ -2,6 +2,7 @@ package paginate
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -33,6 +34,27 @@ func NewRangeWithUnit(start, end int64, unit string) *Range {
 	}
 }
 
+// RangeFromPercent converts a percentage span of a collection of total items
+// into a concrete range, e.g. the 10%-20% slice for sampling. Both bounds are
+// rounded down, so adjacent spans (0-10, 10-20, ...) partition the collection
+// without gaps or overlap.
+// Returns ErrInvalidRange unless 0 <= startPct < endPct <= 100 and total > 0,
+// or if the span is too narrow to contain any item.
+func RangeFromPercent(startPct, endPct float64, total int64) (*Range, error) {
+	if !(startPct >= 0 && startPct < endPct && endPct <= 100) {
+		return nil, fmt.Errorf("%w: percentages %g-%g", ErrInvalidRange, startPct, endPct)
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("%w: total %d", ErrInvalidRange, total)
+	}
+	start := int64(math.Floor(startPct * float64(total) / 100))
+	end := int64(math.Floor(endPct*float64(total)/100)) - 1
+	if end < start {
+		return nil, fmt.Errorf("%w: %g-%g%% of %d contains no items", ErrInvalidRange, startPct, endPct, total)
+	}
+	return NewRange(start, min(end, total-1)), nil
+}
+
 // Size returns the number of items in the range.
 func (r *Range) Size() int64 {
 	if r.End < r.Start {
@@ -57,6 +79,38 @@ func (r *Range) SQLClause() string {
 	return fmt.Sprintf("LIMIT %d OFFSET %d", r.Size(), r.Start)
 }
 
+// Intersect returns the overlap of r and other, e.g. a requested range and
+// the available [0, total-1], and true. It returns nil and false if the
+// ranges are disjoint, which makes the request unsatisfiable (416).
+// The unit of r is kept.
+func (r *Range) Intersect(other *Range) (*Range, bool) {
+	start := max(r.Start, other.Start)
+	end := min(r.End, other.End)
+	if start > end || r.Size() == 0 || other.Size() == 0 {
+		return nil, false
+	}
+	return &Range{Start: start, End: end, Unit: r.Unit}, true
+}
+
+// Split divides the range into consecutive sub-ranges of chunkSize items
+// covering [Start, End], for fetching in parallel. The last sub-range may be
+// shorter. Returns nil if chunkSize <= 0 or the range is empty.
+func (r *Range) Split(chunkSize int64) []*Range {
+	if chunkSize <= 0 || r.Size() == 0 {
+		return nil
+	}
+
+	chunks := make([]*Range, 0, (r.Size()+chunkSize-1)/chunkSize)
+	for start := r.Start; start <= r.End; start += chunkSize {
+		end := min(start+chunkSize-1, r.End)
+		chunks = append(chunks, &Range{Start: start, End: end, Unit: r.Unit})
+		if end == r.End {
+			break
+		}
+	}
+	return chunks
+}
+
 // Header returns the Range header value.
 // Example: "items=0-24"
 func (r *Range) Header() string {
@@ -74,16 +128,24 @@ func (r *Range) ContentRangeHeader(total int64) string {
 }
 
 // RangeResponse represents a range-based pagination response.
+// Set Descending for feeds that are walked from the newest item (highest
+// index) towards index 0; Start and End may then count down.
 type RangeResponse[T any] struct {
-	Items []T    `json:"items"`
-	Start int64  `json:"start"`
-	End   int64  `json:"end"`
-	Total int64  `json:"total"`
-	Unit  string `json:"unit"`
+	Items      []T    `json:"items"`
+	Start      int64  `json:"start"`
+	End        int64  `json:"end"`
+	Total      int64  `json:"total"`
+	Unit       string `json:"unit"`
+	Descending bool   `json:"descending,omitempty"`
+
+	// requested is the size of the requested range, used by HasMore without
+	// a total and by NextRange and PrevRange.
+	requested int64
 }
 
 // NewRangeResponse creates a new range response.
 // The actual end is calculated based on the number of items returned.
+// Pass UnknownTotal if the total is not known.
 func NewRangeResponse[T any](items []T, r *Range, total int64) *RangeResponse[T] {
 	actualEnd := r.Start
 	if len(items) > 0 {
@@ -91,27 +153,170 @@ func NewRangeResponse[T any](items []T, r *Range, total int64) *RangeResponse[T]
 	}
 
 	return &RangeResponse[T]{
-		Items: items,
-		Start: r.Start,
-		End:   actualEnd,
-		Total: total,
-		Unit:  r.Unit,
+		Items:     items,
+		Start:     r.Start,
+		End:       actualEnd,
+		Total:     total,
+		Unit:      r.Unit,
+		requested: r.Size(),
 	}
 }
 
+// NewRangeResponseUnknownTotal creates a range response for count-free range
+// APIs. It is equivalent to NewRangeResponse with UnknownTotal.
+func NewRangeResponseUnknownTotal[T any](items []T, r *Range) *RangeResponse[T] {
+	return NewRangeResponse(items, r, UnknownTotal)
+}
+
 // ContentRange returns the Content-Range header value.
+// The range is always emitted low-high, even for descending responses.
+// An unknown (negative) total is emitted as "*". Unsatisfiable ranges and
+// empty responses use the bare-total form, e.g. "items */100".
 func (r *RangeResponse[T]) ContentRange() string {
-	if len(r.Items) == 0 {
-		return fmt.Sprintf("%s */%d", r.Unit, r.Total)
+	total := "*"
+	if r.Total >= 0 {
+		total = strconv.FormatInt(r.Total, 10)
+	}
+	if len(r.Items) == 0 || r.Unsatisfiable() {
+		return fmt.Sprintf("%s */%s", r.Unit, total)
 	}
-	return fmt.Sprintf("%s %d-%d/%d", r.Unit, r.Start, r.End, r.Total)
+	low, high := r.bounds()
+	return fmt.Sprintf("%s %d-%d/%s", r.Unit, low, high, total)
 }
 
 // HasMore returns true if there are more items after this range.
+// For descending responses, more items exist below the lowest index returned.
+// If the total is unknown, a non-empty response has more only if it filled
+// the requested range, since a short response means the end of the data was
+// reached.
 func (r *RangeResponse[T]) HasMore() bool {
+	if r.Descending {
+		low, _ := r.bounds()
+		return low > 0
+	}
+	if r.Total < 0 {
+		return len(r.Items) > 0 && int64(len(r.Items)) >= r.requested
+	}
 	return r.End < r.Total-1
 }
 
+// Unsatisfiable returns true if the requested range starts at or beyond the
+// end of the collection. For an empty collection (total 0) only a range
+// starting at 0 is satisfiable. An unknown total is never unsatisfiable.
+func (r *RangeResponse[T]) Unsatisfiable() bool {
+	low, _ := r.bounds()
+	if r.Total == 0 {
+		return low > 0
+	}
+	return r.Total > 0 && low >= r.Total
+}
+
+// StatusCode returns the HTTP status code for the response:
+//   - 416 Range Not Satisfiable if the range starts beyond the collection
+//   - 200 OK if the collection is empty or fully covered by the response
+//   - 206 Partial Content otherwise
+func (r *RangeResponse[T]) StatusCode() int {
+	if r.Unsatisfiable() {
+		return http.StatusRequestedRangeNotSatisfiable
+	}
+	if r.Total == 0 {
+		return http.StatusOK
+	}
+	low, high := r.bounds()
+	if len(r.Items) > 0 && low == 0 && high >= r.Total-1 {
+		return http.StatusOK
+	}
+	return http.StatusPartialContent
+}
+
+// ContentRangeAndStatus returns the Content-Range header value together with
+// the matching HTTP status code. Both a satisfiable request on an empty
+// collection and an unsatisfiable one report the bare "items */0" form, so
+// for a zero total the status disambiguates them.
+func (r *RangeResponse[T]) ContentRangeAndStatus() (header string, status int) {
+	return r.ContentRange(), r.StatusCode()
+}
+
+// SetAllHeaders sets the Content-Range, Accept-Ranges and X-Page-Count (the
+// number of items returned) headers in one call, the range counterpart of
+// Page.WriteHeaders:
+//
+//	resp.SetAllHeaders(w.Header().Set)
+//	w.WriteHeader(resp.StatusCode())
+func (r *RangeResponse[T]) SetAllHeaders(set func(key, value string)) {
+	set("Content-Range", r.ContentRange())
+	set("Accept-Ranges", r.Unit)
+	set("X-Page-Count", strconv.Itoa(len(r.Items)))
+}
+
+// NextRange returns the window of the same size following this response,
+// and true. The window is clamped to the end of the collection when the
+// total is known. Returns false if HasMore reports no further items or the
+// response is empty. For descending responses the next window lies towards
+// index 0.
+func (r *RangeResponse[T]) NextRange() (*Range, bool) {
+	if !r.HasMore() {
+		return nil, false
+	}
+	if r.Descending {
+		return r.lowerRange()
+	}
+	return r.higherRange()
+}
+
+// PrevRange returns the window of the same size preceding this response,
+// and true. The window is clamped at index 0. Returns false at the start of
+// the collection or if the response is empty. For descending responses the
+// previous window lies towards the end of the collection.
+func (r *RangeResponse[T]) PrevRange() (*Range, bool) {
+	if r.Descending {
+		return r.higherRange()
+	}
+	return r.lowerRange()
+}
+
+// windowSize returns the size of the requested window, falling back to the
+// number of items returned.
+func (r *RangeResponse[T]) windowSize() int64 {
+	if r.requested > 0 {
+		return r.requested
+	}
+	return int64(len(r.Items))
+}
+
+// higherRange returns the adjacent window above the response's indices.
+func (r *RangeResponse[T]) higherRange() (*Range, bool) {
+	size := r.windowSize()
+	_, high := r.bounds()
+	start := high + 1
+	if len(r.Items) == 0 || (r.Total >= 0 && start >= r.Total) {
+		return nil, false
+	}
+	end := start + size - 1
+	if r.Total >= 0 {
+		end = min(end, r.Total-1)
+	}
+	return &Range{Start: start, End: end, Unit: r.Unit}, true
+}
+
+// lowerRange returns the adjacent window below the response's indices.
+func (r *RangeResponse[T]) lowerRange() (*Range, bool) {
+	size := r.windowSize()
+	low, _ := r.bounds()
+	if len(r.Items) == 0 || low <= 0 {
+		return nil, false
+	}
+	return &Range{Start: max(low-size, 0), End: low - 1, Unit: r.Unit}, true
+}
+
+// bounds returns the lowest and highest indices covered by the response.
+func (r *RangeResponse[T]) bounds() (low, high int64) {
+	if r.Start > r.End {
+		return r.End, r.Start
+	}
+	return r.Start, r.End
+}
+
 // Empty returns true if the response has no items.
 func (r *RangeResponse[T]) Empty() bool {
 	return len(r.Items) == 0
@@ -122,6 +327,65 @@ func (r *RangeResponse[T]) Count() int {
 	return len(r.Items)
 }
 
+// MultiRangeResponse represents a response to a multi-range request, with one
+// part per satisfiable range (like an RFC 7233 multipart/byteranges response).
+type MultiRangeResponse[T any] struct {
+	Parts []RangeResponse[T] `json:"parts"`
+	Total int64              `json:"total"`
+	Unit  string             `json:"unit"`
+}
+
+// NewMultiRangeResponse builds a multi-range response by slicing the full
+// item collection for each requested range. Ranges that start beyond the
+// available items are unsatisfiable and are skipped, as are invalid ranges
+// (see Range.Validate); ranges that extend past the end are truncated.
+func NewMultiRangeResponse[T any](items []T, ranges []*Range) *MultiRangeResponse[T] {
+	total := int64(len(items))
+	resp := &MultiRangeResponse[T]{
+		Total: total,
+		Unit:  "items",
+	}
+
+	for _, r := range ranges {
+		if r == nil || r.Validate() != nil || r.Start >= total {
+			continue
+		}
+		end := min(r.End, total-1)
+		resp.Parts = append(resp.Parts, *NewRangeResponse(items[r.Start:end+1], r, total))
+		resp.Unit = r.Unit
+	}
+
+	return resp
+}
+
+// ContentRanges returns the Content-Range header value of each part.
+func (m *MultiRangeResponse[T]) ContentRanges() []string {
+	headers := make([]string, len(m.Parts))
+	for i := range m.Parts {
+		headers[i] = m.Parts[i].ContentRange()
+	}
+	return headers
+}
+
+// ContentRange returns a Content-Range value describing the combined
+// coverage of all parts, from the lowest to the highest index returned.
+func (m *MultiRangeResponse[T]) ContentRange() string {
+	if len(m.Parts) == 0 {
+		return fmt.Sprintf("%s */%d", m.Unit, m.Total)
+	}
+	low, high := m.Parts[0].bounds()
+	for _, part := range m.Parts[1:] {
+		l, h := part.bounds()
+		low, high = min(low, l), max(high, h)
+	}
+	return fmt.Sprintf("%s %d-%d/%d", m.Unit, low, high, m.Total)
+}
+
+// Empty returns true if no range could be satisfied.
+func (m *MultiRangeResponse[T]) Empty() bool {
+	return len(m.Parts) == 0
+}
+
 // Regular expression for parsing Range headers.
 // Matches patterns like "items=0-24" or "bytes=100-199"
 var rangeRegex = regexp.MustCompile(`^(\w+)=(\d+)-(\d*)$`)
@@ -184,9 +448,37 @@ func RangeFromOffsetLimit(offset, limit int) *Range {
 	}
 }
 
+// RangeForOffset returns the windowSize-aligned range containing offset, for
+// UIs that jump to arbitrary scroll positions. The window is clamped to
+// [0, total); near the end it is shifted back so the last window is not
+// shorter than windowSize when the collection is large enough.
+// A windowSize <= 0 uses DefaultPageSize. Pass UnknownTotal to skip clamping
+// at the end. Returns nil if total is 0, as no window can be satisfied.
+func RangeForOffset(offset, windowSize, total int64) *Range {
+	if total == 0 {
+		return nil
+	}
+	if windowSize <= 0 {
+		windowSize = int64(DefaultPageSize)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	start := offset / windowSize * windowSize
+	end := start + windowSize - 1
+
+	if total > 0 && end >= total {
+		end = total - 1
+		start = max(total-windowSize, 0)
+	}
+
+	return NewRange(start, end)
+}
+
 // ToPaginator converts a range to an offset-based paginator (approximate).
 // This is useful for backends that use offset pagination but need to support
-// range-based APIs.
+// range-based APIs. The paginator is 1-based; use WithOptions to renumber it.
 func (r *Range) ToPaginator() *Paginator {
 	pageSize := int(r.Size())
 	if pageSize <= 0 {
@@ -196,6 +488,6 @@ func (r *Range) ToPaginator() *Paginator {
 		pageSize = MaxPageSize
 	}
 
-	page := int(r.Start/int64(pageSize)) + 1
+	page := int(r.Start/int64(pageSize)) + DefaultPage
 	return NewFromValues(page, pageSize)
 }
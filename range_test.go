@@ -1,6 +1,8 @@
 package paginate
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"testing"
 )
@@ -75,6 +77,70 @@ func TestRangeValidate(t *testing.T) {
 	}
 }
 
+func TestRangeIntersect(t *testing.T) {
+	tests := []struct {
+		name       string
+		r, other   *Range
+		start, end int64
+		ok         bool
+	}{
+		{"Inside", NewRange(10, 19), NewRange(0, 99), 10, 19, true},
+		{"Overlaps end", NewRange(90, 109), NewRange(0, 99), 90, 99, true},
+		{"Overlaps start", NewRange(0, 9), NewRange(5, 99), 5, 9, true},
+		{"Single item", NewRange(99, 120), NewRange(0, 99), 99, 99, true},
+		{"Disjoint", NewRange(100, 109), NewRange(0, 99), 0, 0, false},
+		{"Empty available", NewRange(0, 9), NewRange(0, -1), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.Intersect(tt.other)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if !ok {
+				if got != nil {
+					t.Errorf("Expected nil range, got %+v", got)
+				}
+				return
+			}
+			if got.Start != tt.start || got.End != tt.end || got.Unit != tt.r.Unit {
+				t.Errorf("Expected %d-%d, got %+v", tt.start, tt.end, got)
+			}
+		})
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	tests := []struct {
+		name      string
+		r         *Range
+		chunkSize int64
+		expected  []string
+	}{
+		{"Even", NewRange(0, 999), 250, []string{"items=0-249", "items=250-499", "items=500-749", "items=750-999"}},
+		{"Shorter last chunk", NewRange(10, 24), 10, []string{"items=10-19", "items=20-24"}},
+		{"Chunk larger than range", NewRange(0, 4), 100, []string{"items=0-4"}},
+		{"Single item chunks", NewRange(5, 7), 1, []string{"items=5-5", "items=6-6", "items=7-7"}},
+		{"Zero chunk size", NewRange(0, 9), 0, nil},
+		{"Empty range", NewRange(5, 4), 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := tt.r.Split(tt.chunkSize)
+			if len(chunks) != len(tt.expected) {
+				t.Fatalf("Expected %d chunks, got %d", len(tt.expected), len(chunks))
+			}
+			for i, chunk := range chunks {
+				if chunk.Header() != tt.expected[i] {
+					t.Errorf("Chunk %d: expected %s, got %s", i, tt.expected[i], chunk.Header())
+				}
+			}
+		})
+	}
+}
+
 func TestRangeSQLClause(t *testing.T) {
 	r := NewRange(40, 59)
 	expected := "LIMIT 20 OFFSET 40"
@@ -277,6 +343,39 @@ func TestRangeToPaginator(t *testing.T) {
 	}
 }
 
+func TestRangeForOffset(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset        int64
+		windowSize    int64
+		total         int64
+		expectedStart int64
+		expectedEnd   int64
+	}{
+		{"Aligned offset", 50, 25, 1000, 50, 74},
+		{"Unaligned offset", 60, 25, 1000, 50, 74},
+		{"Negative offset", -5, 25, 1000, 0, 24},
+		{"Near end", 990, 25, 1000, 975, 999},
+		{"Beyond end", 5000, 25, 1000, 975, 999},
+		{"Smaller than window", 3, 25, 10, 0, 9},
+		{"Unknown total", 5000, 25, UnknownTotal, 5000, 5024},
+		{"Default window", 30, 0, 1000, 20, 39},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RangeForOffset(tt.offset, tt.windowSize, tt.total)
+			if r.Start != tt.expectedStart || r.End != tt.expectedEnd {
+				t.Errorf("Expected %d-%d, got %d-%d", tt.expectedStart, tt.expectedEnd, r.Start, r.End)
+			}
+		})
+	}
+
+	if r := RangeForOffset(10, 25, 0); r != nil {
+		t.Errorf("Expected nil range for empty collection, got %+v", r)
+	}
+}
+
 func TestNewRangeResponse(t *testing.T) {
 	items := []string{"a", "b", "c"}
 	r := NewRange(10, 15)
@@ -298,6 +397,30 @@ func TestNewRangeResponse(t *testing.T) {
 	}
 }
 
+func TestNewRangeResponseUnknownTotal(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		hasMore bool
+	}{
+		{"Full range", []string{"a", "b", "c"}, true},
+		{"Short range", []string{"a", "b"}, false},
+		{"Empty", []string{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := NewRangeResponseUnknownTotal(tt.items, NewRange(10, 12))
+			if resp.Total != UnknownTotal {
+				t.Errorf("Expected unknown total, got %d", resp.Total)
+			}
+			if resp.HasMore() != tt.hasMore {
+				t.Errorf("Expected HasMore=%v, got %v", tt.hasMore, resp.HasMore())
+			}
+		})
+	}
+}
+
 func TestRangeResponseEmpty(t *testing.T) {
 	r := NewRange(0, 10)
 	resp := NewRangeResponse([]string{}, r, 0)
@@ -358,3 +481,250 @@ func TestRangeResponseHasMore(t *testing.T) {
 		})
 	}
 }
+
+func TestRangeResponseDescending(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         int64
+		end           int64
+		total         int64
+		expectedMore  bool
+		expectedRange string
+	}{
+		{"Newest page", 99, 80, 100, true, "items 80-99/100"},
+		{"Ascending indices", 80, 99, 100, true, "items 80-99/100"},
+		{"Oldest page", 19, 0, 100, false, "items 0-19/100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &RangeResponse[string]{
+				Items:      []string{"a"},
+				Start:      tt.start,
+				End:        tt.end,
+				Total:      tt.total,
+				Unit:       "items",
+				Descending: true,
+			}
+
+			if hasMore := resp.HasMore(); hasMore != tt.expectedMore {
+				t.Errorf("Expected HasMore=%v, got %v", tt.expectedMore, hasMore)
+			}
+			if cr := resp.ContentRange(); cr != tt.expectedRange {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedRange, cr)
+			}
+		})
+	}
+}
+
+func TestNewMultiRangeResponse(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	resp := NewMultiRangeResponse(items, []*Range{
+		NewRange(0, 9),
+		NewRange(50, 54),
+		NewRange(95, 120),
+		NewRange(200, 210),
+	})
+
+	if len(resp.Parts) != 3 {
+		t.Fatalf("Expected 3 parts, got %d", len(resp.Parts))
+	}
+	if resp.Parts[1].Items[0] != 50 || resp.Parts[1].Count() != 5 {
+		t.Errorf("Unexpected second part: %+v", resp.Parts[1])
+	}
+	if resp.Parts[2].Count() != 5 {
+		t.Errorf("Expected truncated third part of 5 items, got %d", resp.Parts[2].Count())
+	}
+
+	expected := []string{"items 0-9/100", "items 50-54/100", "items 95-99/100"}
+	for i, cr := range resp.ContentRanges() {
+		if cr != expected[i] {
+			t.Errorf("Part %d: expected '%s', got '%s'", i, expected[i], cr)
+		}
+	}
+	if cr := resp.ContentRange(); cr != "items 0-99/100" {
+		t.Errorf("Expected 'items 0-99/100', got '%s'", cr)
+	}
+
+	empty := NewMultiRangeResponse(items, []*Range{NewRange(200, 210), NewRange(-2, 5), NewRange(9, 3)})
+	if !empty.Empty() {
+		t.Error("Expected empty response for unsatisfiable and invalid ranges")
+	}
+	if cr := empty.ContentRange(); cr != "items */100" {
+		t.Errorf("Expected 'items */100', got '%s'", cr)
+	}
+}
+
+func TestRangeResponseUnsatisfiable(t *testing.T) {
+	tests := []struct {
+		name          string
+		items         []string
+		start         int64
+		end           int64
+		total         int64
+		unsatisfiable bool
+		status        int
+		contentRange  string
+	}{
+		{"Partial", []string{"a", "b"}, 0, 1, 100, false, http.StatusPartialContent, "items 0-1/100"},
+		{"Full collection", []string{"a", "b"}, 0, 1, 2, false, http.StatusOK, "items 0-1/2"},
+		{"Beyond total", nil, 100, 109, 100, true, http.StatusRequestedRangeNotSatisfiable, "items */100"},
+		{"Far beyond total", nil, 500, 509, 100, true, http.StatusRequestedRangeNotSatisfiable, "items */100"},
+		{"Empty collection", nil, 0, 9, 0, false, http.StatusOK, "items */0"},
+		{"Beyond empty collection", nil, 5, 9, 0, true, http.StatusRequestedRangeNotSatisfiable, "items */0"},
+		{"Unknown total", []string{"a"}, 500, 500, UnknownTotal, false, http.StatusPartialContent, "items 500-500/*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := NewRangeResponse(tt.items, NewRange(tt.start, tt.end), tt.total)
+
+			if got := resp.Unsatisfiable(); got != tt.unsatisfiable {
+				t.Errorf("Expected Unsatisfiable=%v, got %v", tt.unsatisfiable, got)
+			}
+			if got := resp.StatusCode(); got != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, got)
+			}
+			if got := resp.ContentRange(); got != tt.contentRange {
+				t.Errorf("Expected '%s', got '%s'", tt.contentRange, got)
+			}
+		})
+	}
+}
+
+func TestRangeResponseContentRangeAndStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		items        []string
+		start, end   int64
+		total        int64
+		contentRange string
+		status       int
+	}{
+		{"Empty collection", nil, 0, 9, 0, "items */0", http.StatusOK},
+		{"Beyond empty collection", nil, 10, 19, 0, "items */0", http.StatusRequestedRangeNotSatisfiable},
+		{"Beyond total", nil, 100, 109, 100, "items */100", http.StatusRequestedRangeNotSatisfiable},
+		{"Partial", []string{"a", "b"}, 0, 1, 100, "items 0-1/100", http.StatusPartialContent},
+		{"Full collection", []string{"a", "b"}, 0, 1, 2, "items 0-1/2", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := NewRangeResponse(tt.items, NewRange(tt.start, tt.end), tt.total)
+			header, status := resp.ContentRangeAndStatus()
+			if header != tt.contentRange || status != tt.status {
+				t.Errorf("Expected (%s, %d), got (%s, %d)", tt.contentRange, tt.status, header, status)
+			}
+		})
+	}
+}
+
+func TestRangeFromPercent(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end float64
+		total      int64
+		expected   string
+	}{
+		{"Tenth slice", 10, 20, 1000, "items=100-199"},
+		{"Whole", 0, 100, 7, "items=0-6"},
+		{"Rounded down", 10, 40, 7, "items=0-1"},
+		{"Next adjacent slice", 40, 60, 7, "items=2-3"},
+		{"Single item", 50, 60, 10, "items=5-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := RangeFromPercent(tt.start, tt.end, tt.total)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if r.Header() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, r.Header())
+			}
+		})
+	}
+}
+
+func TestRangeFromPercentErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end float64
+		total      int64
+	}{
+		{"Negative start", -1, 10, 100},
+		{"Empty span", 10, 10, 100},
+		{"Reversed", 20, 10, 100},
+		{"Above 100", 90, 101, 100},
+		{"NaN", math.NaN(), 10, 100},
+		{"Empty collection", 0, 10, 0},
+		{"No items in span", 0, 10, 7},
+		{"Tiny span", 50, 50.01, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RangeFromPercent(tt.start, tt.end, tt.total); !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("Expected ErrInvalidRange, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRangeResponseSetAllHeaders(t *testing.T) {
+	resp := NewRangeResponse([]string{"a", "b", "c"}, NewRange(10, 19), 12)
+
+	headers := http.Header{}
+	resp.SetAllHeaders(headers.Set)
+
+	expected := map[string]string{
+		"Content-Range": "items 10-12/12",
+		"Accept-Ranges": "items",
+		"X-Page-Count":  "3",
+	}
+	for k, v := range expected {
+		if got := headers.Get(k); got != v {
+			t.Errorf("Expected %s %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestRangeResponseNextPrevRange(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name       string
+		resp       *RangeResponse[string]
+		next, prev string
+	}{
+		{"First window", NewRangeResponse(items, NewRange(0, 4), 12), "items=5-9", ""},
+		{"Middle window", NewRangeResponse(items, NewRange(5, 9), 12), "items=10-11", "items=0-4"},
+		{"Last window", NewRangeResponse(items[:2], NewRange(10, 14), 12), "", "items=5-9"},
+		{"Unknown total", NewRangeResponseUnknownTotal(items, NewRange(5, 9)), "items=10-14", "items=0-4"},
+		{"Unknown total short", NewRangeResponseUnknownTotal(items[:3], NewRange(0, 9)), "", ""},
+		{"Prev clamped at zero", NewRangeResponse(items, NewRange(3, 7), 12), "items=8-11", "items=0-2"},
+		{"Empty", NewRangeResponse([]string{}, NewRange(20, 24), 12), "", ""},
+		{
+			"Descending",
+			&RangeResponse[string]{Items: items, Start: 9, End: 5, Total: 12, Unit: "items", Descending: true},
+			"items=0-4", "items=10-11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := tt.resp.NextRange()
+			if ok != (tt.next != "") || (ok && next.Header() != tt.next) {
+				t.Errorf("Expected next %q, got %v (%v)", tt.next, next, ok)
+			}
+			prev, ok := tt.resp.PrevRange()
+			if ok != (tt.prev != "") || (ok && prev.Header() != tt.prev) {
+				t.Errorf("Expected prev %q, got %v (%v)", tt.prev, prev, ok)
+			}
+		})
+	}
+}
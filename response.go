@@ -1,8 +1,11 @@
 package paginate
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
 	"net/url"
+	"strconv"
 )
 
 // Page represents a paginated response using offset pagination.
@@ -14,12 +17,61 @@ type Page[T any] struct {
 	TotalPages int   `json:"total_pages"`
 	HasPrev    bool  `json:"has_prev"`
 	HasNext    bool  `json:"has_next"`
+
+	// Meta holds extra metadata (e.g. applied filters or requested fields)
+	// emitted under a "meta" key alongside the standard fields.
+	Meta map[string]any `json:"meta,omitempty"`
+
+	// Links holds navigation links for clients that read them from the body
+	// rather than the Link header. See NewPageWithLinks.
+	Links *LinkHeader `json:"links,omitempty"`
+
+	// opts are the options of the paginator the page was built from.
+	opts Options
+}
+
+// PageOptions controls which metadata fields a PageView emits as JSON.
+type PageOptions struct {
+	// OmitNavigation omits has_prev and has_next.
+	OmitNavigation bool
+}
+
+// PageView is a Page encoded according to PageOptions. Total and total_pages
+// are omitted whenever the total is unknown, for count-free paging. It is a
+// separate type so that structs embedding Page keep the default encoding.
+type PageView[T any] struct {
+	Page    *Page[T]
+	Options PageOptions
+}
+
+// pageJSON is the wire format of a PageView. Optional fields are pointers so
+// they can be omitted.
+type pageJSON[T any] struct {
+	Items      []T    `json:"items"`
+	Total      *int64 `json:"total,omitempty"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	HasPrev    *bool  `json:"has_prev,omitempty"`
+	HasNext    *bool  `json:"has_next,omitempty"`
+
+	Meta  map[string]any `json:"meta,omitempty"`
+	Links *LinkHeader    `json:"links,omitempty"`
 }
 
 // NewPage creates a new paginated response.
+// Pass UnknownTotal if the total is not known; HasNext is then inferred from
+// whether a full page of items was returned, and is false for unlimited
+// paginators, which return everything. Encode the page through View to omit
+// the unknown total from the JSON.
 func NewPage[T any](items []T, total int64, p *Paginator) *Page[T] {
 	totalPages := p.TotalPages(total)
 
+	hasNext := p.HasNext(total)
+	if total < 0 {
+		hasNext = p.PageSize > 0 && len(items) >= p.PageSize
+	}
+
 	return &Page[T]{
 		Items:      items,
 		Total:      total,
@@ -27,10 +79,82 @@ func NewPage[T any](items []T, total int64, p *Paginator) *Page[T] {
 		PageSize:   p.PageSize,
 		TotalPages: totalPages,
 		HasPrev:    p.HasPrevious(),
-		HasNext:    p.HasNext(total),
+		HasNext:    hasNext,
+		opts:       p.opts,
 	}
 }
 
+// NewPageAuto creates a paginated response in either count-based or
+// count-free mode. When overfetched is true, items are expected to hold up to
+// PageSize+1 rows: HasNext is derived from the extra row, which is trimmed,
+// and Total is UnknownTotal. Otherwise it behaves like NewPage with total.
+func NewPageAuto[T any](items []T, p *Paginator, total int64, overfetched bool) *Page[T] {
+	if !overfetched {
+		return NewPage(items, total, p)
+	}
+
+	items, hasNext := TrimForHasMore(items, p.Limit())
+	page := NewPage(items, UnknownTotal, p)
+	page.HasNext = hasNext
+	return page
+}
+
+// NewPageWithLinks creates a paginated response with navigation links for
+// baseURL embedded in the body. With an unknown total, the links are built
+// like BuildLinkHeaderNoTotal and Last is omitted.
+func NewPageWithLinks[T any](items []T, total int64, p *Paginator, baseURL string) *Page[T] {
+	page := NewPage(items, total, p)
+	if total < 0 {
+		page.Links = BuildLinkHeaderNoTotal(baseURL, p, page.HasNext)
+	} else {
+		page.Links = BuildLinkHeader(baseURL, p, total)
+	}
+	return page
+}
+
+// NewPageWithOptions creates a new paginated response whose JSON output is
+// controlled by opts.
+func NewPageWithOptions[T any](items []T, total int64, p *Paginator, opts PageOptions) *PageView[T] {
+	return NewPage(items, total, p).View(opts)
+}
+
+// View returns the page wrapped for encoding according to opts.
+func (p *Page[T]) View(opts PageOptions) *PageView[T] {
+	return &PageView[T]{Page: p, Options: opts}
+}
+
+// WithMeta returns a copy of the page with extra metadata merged into Meta.
+// Existing keys are overwritten by extra; the standard fields are unaffected.
+func (p *Page[T]) WithMeta(extra map[string]any) *Page[T] {
+	clone := *p
+	clone.Meta = make(map[string]any, len(p.Meta)+len(extra))
+	maps.Copy(clone.Meta, p.Meta)
+	maps.Copy(clone.Meta, extra)
+	return &clone
+}
+
+// MarshalJSON encodes the page, omitting total and total_pages when the total
+// is unknown and any fields disabled by the options.
+func (v PageView[T]) MarshalJSON() ([]byte, error) {
+	p := v.Page
+	out := pageJSON[T]{
+		Items:    p.Items,
+		Page:     p.Page,
+		PageSize: p.PageSize,
+		Meta:     p.Meta,
+		Links:    p.Links,
+	}
+	if p.Total >= 0 {
+		out.Total = &p.Total
+		out.TotalPages = &p.TotalPages
+	}
+	if !v.Options.OmitNavigation {
+		out.HasPrev = &p.HasPrev
+		out.HasNext = &p.HasNext
+	}
+	return json.Marshal(out)
+}
+
 // Empty returns true if the page has no items.
 func (p *Page[T]) Empty() bool {
 	return len(p.Items) == 0
@@ -41,6 +165,71 @@ func (p *Page[T]) Count() int {
 	return len(p.Items)
 }
 
+// HeaderNames configures the response headers written by
+// Page.WriteHeadersWithNames. An empty name disables that header.
+type HeaderNames struct {
+	Total        string
+	TotalPages   string
+	ContentRange string
+}
+
+// DefaultHeaderNames are the header names used by Page.WriteHeaders.
+var DefaultHeaderNames = HeaderNames{
+	Total:        "X-Total-Count",
+	TotalPages:   "X-Total-Pages",
+	ContentRange: "Content-Range",
+}
+
+// WriteHeaders writes pagination headers using DefaultHeaderNames.
+func (p *Page[T]) WriteHeaders(set func(key, value string)) {
+	p.WriteHeadersWithNames(set, DefaultHeaderNames)
+}
+
+// WriteHeadersWithNames writes the total, total pages and Content-Range
+// headers under the given names, so one handler can serve frontends that
+// expect different headers (e.g. Content-Range for react-admin, X-Total-Count
+// for other grids). The Content-Range covers the page's items in the "items"
+// unit. Total and total pages are skipped when the total is unknown.
+func (p *Page[T]) WriteHeadersWithNames(set func(key, value string), names HeaderNames) {
+	if p.Total >= 0 {
+		if names.Total != "" {
+			set(names.Total, strconv.FormatInt(p.Total, 10))
+		}
+		if names.TotalPages != "" {
+			set(names.TotalPages, strconv.Itoa(p.TotalPages))
+		}
+	}
+	if names.ContentRange != "" {
+		start := int64(p.Page-p.opts.firstPage()) * int64(p.PageSize)
+		rng := NewRange(start, start+int64(len(p.Items))-1)
+		set(names.ContentRange, NewRangeResponse(p.Items, rng, p.Total).ContentRange())
+	}
+}
+
+// PageReduce folds the page's items into a single value, e.g. the sum of a
+// column for a data grid footer. It returns init for an empty or nil page.
+func PageReduce[T, A any](page *Page[T], init A, fn func(A, T) A) A {
+	acc := init
+	if page == nil {
+		return acc
+	}
+	for _, item := range page.Items {
+		acc = fn(acc, item)
+	}
+	return acc
+}
+
+// IsFirst returns true if this is the first page.
+func (p *Page[T]) IsFirst() bool {
+	return p.Page == p.opts.firstPage()
+}
+
+// IsLast returns true if there is no next page. It relies on HasNext, so it
+// also works for count-free pages built with UnknownTotal or NewPageAuto.
+func (p *Page[T]) IsLast() bool {
+	return !p.HasNext
+}
+
 // CursorPage represents a paginated response using cursor pagination.
 type CursorPage[T any] struct {
 	Items      []T    `json:"items"`
@@ -48,6 +237,14 @@ type CursorPage[T any] struct {
 	PrevCursor string `json:"prev_cursor,omitempty"`
 	HasMore    bool   `json:"has_more"`
 	Limit      int    `json:"limit"`
+
+	// TotalCount is the total number of items, if counted. It is typically
+	// only set on the first page; see WithTotalCount.
+	TotalCount *int64 `json:"total_count,omitempty"`
+
+	// Links holds navigation links for clients that read them from the body
+	// rather than the Link header. See NewCursorPageWithLinks.
+	Links *LinkHeader `json:"links,omitempty"`
 }
 
 // NewCursorPage creates a new cursor-paginated response.
@@ -61,6 +258,25 @@ func NewCursorPage[T any](items []T, limit int, nextCursor, prevCursor string, h
 	}
 }
 
+// NewCursorPageWithLinks creates a cursor-paginated response with next and
+// previous links for baseURL embedded in the body.
+func NewCursorPageWithLinks[T any](items []T, limit int, nextCursor, prevCursor string, hasMore bool, baseURL string) *CursorPage[T] {
+	page := NewCursorPage(items, limit, nextCursor, prevCursor, hasMore)
+	page.Links = BuildCursorLinkHeader(baseURL, page, limit)
+	return page
+}
+
+// NewEmptyCursorPage creates an empty cursor page with HasMore=false, e.g.
+// for backward paging from the first item (see
+// CursorPaginator.IsBackwardStart). Items is an empty, non-nil slice so it
+// serializes as [].
+func NewEmptyCursorPage[T any](limit int) *CursorPage[T] {
+	return &CursorPage[T]{
+		Items: []T{},
+		Limit: limit,
+	}
+}
+
 // NewCursorPageSimple creates a simple cursor page with just a next cursor.
 // This is useful when you only need forward pagination.
 func NewCursorPageSimple[T any](items []T, limit int, nextCursor string) *CursorPage[T] {
@@ -72,6 +288,53 @@ func NewCursorPageSimple[T any](items []T, limit int, nextCursor string) *Cursor
 	}
 }
 
+// WithTotalCount returns a copy of the page with TotalCount set, but only if
+// c is on the first page (see CursorPaginator.ShouldCount). Subsequent pages
+// omit total_count, so the count query only runs once per walk.
+func (p *CursorPage[T]) WithTotalCount(total int64, c *CursorPaginator) *CursorPage[T] {
+	clone := *p
+	clone.TotalCount = nil
+	if c.ShouldCount() {
+		clone.TotalCount = &total
+	}
+	return &clone
+}
+
+// ToConnection converts the cursor page to a GraphQL-style connection, e.g.
+// for a gateway stitching REST cursor endpoints. Edge cursors are built with
+// cursorFn. HasNextPage mirrors HasMore, and HasPreviousPage is true if
+// hasPrev is set or the page has a PrevCursor.
+// Pass UnknownTotal if the total count is not known.
+func (p *CursorPage[T]) ToConnection(cursorFn func(T) string, hasPrev bool, total int64) *Connection[T] {
+	return NewConnection(p.Items, cursorFn, hasPrev || p.PrevCursor != "", p.HasMore, total)
+}
+
+// Cursors returns the cursors of the first and last items, for building the
+// previous and next links of a page in one call. Both are empty if items is
+// empty; for a single item they are equal.
+func Cursors[T any](items []T, cursorFn func(T) string) (first, last string) {
+	if len(items) == 0 {
+		return "", ""
+	}
+	return cursorFn(items[0]), cursorFn(items[len(items)-1])
+}
+
+// DetectHasMore reports whether more items exist beyond limit using the
+// overfetch convention. It assumes the caller fetched limit+1 items; the
+// items are not trimmed.
+func DetectHasMore[T any](items []T, limit int) bool {
+	return limit >= 0 && len(items) > limit
+}
+
+// TrimForHasMore trims an overfetched result to limit items and reports
+// whether more items exist. It assumes the caller fetched limit+1 items.
+func TrimForHasMore[T any](items []T, limit int) ([]T, bool) {
+	if !DetectHasMore(items, limit) {
+		return items, false
+	}
+	return items[:limit], true
+}
+
 // Empty returns true if the page has no items.
 func (p *CursorPage[T]) Empty() bool {
 	return len(p.Items) == 0
@@ -82,6 +345,24 @@ func (p *CursorPage[T]) Count() int {
 	return len(p.Items)
 }
 
+// NextQuery returns the query parameters for requesting the next page
+// (after=<NextCursor>&limit=N). Returns empty values if there is no next cursor.
+func (p *CursorPage[T]) NextQuery(limit int) url.Values {
+	if p.NextCursor == "" {
+		return url.Values{}
+	}
+	return NewCursorWithLimit(limit).WithCursor(p.NextCursor).QueryParams()
+}
+
+// PrevQuery returns the query parameters for requesting the previous page
+// (before=<PrevCursor>&limit=N). Returns empty values if there is no previous cursor.
+func (p *CursorPage[T]) PrevQuery(limit int) url.Values {
+	if p.PrevCursor == "" {
+		return url.Values{}
+	}
+	return NewCursorWithLimit(limit).WithCursor(p.PrevCursor).WithForward(false).QueryParams()
+}
+
 // Edge represents a GraphQL-style edge containing a node and cursor.
 type Edge[T any] struct {
 	Node   T      `json:"node"`
@@ -106,6 +387,7 @@ type Connection[T any] struct {
 // NewConnection creates a GraphQL-style connection.
 // The cursorFn is called for each item to generate its cursor.
 // Set hasPrev and hasNext based on your pagination logic.
+// Pass UnknownTotal if the total count is not known.
 func NewConnection[T any](
 	items []T,
 	cursorFn func(T) string,
@@ -141,6 +423,136 @@ func NewConnection[T any](
 	}
 }
 
+// LazyConnection is a GraphQL-style connection whose edge cursors are
+// computed on access instead of up front. Only StartCursor and EndCursor are
+// computed eagerly, so resolvers that never read most edge cursors avoid
+// calling cursorFn for every item.
+//
+// Computed cursors are cached, so EdgeCursor and Edge are not safe for
+// concurrent use; call ToConnection first to share the connection between
+// goroutines.
+type LazyConnection[T any] struct {
+	Items      []T
+	PageInfo   PageInfo
+	TotalCount int64
+
+	cursorFn func(T) string
+	cursors  []string
+	computed []bool
+}
+
+// NewConnectionLazy creates a connection like NewConnection, but calls
+// cursorFn only for the first and last items; the remaining edge cursors are
+// computed on demand by EdgeCursor.
+// Pass UnknownTotal if the total count is not known.
+func NewConnectionLazy[T any](
+	items []T,
+	cursorFn func(T) string,
+	hasPrev, hasNext bool,
+	total int64,
+) *LazyConnection[T] {
+	conn := &LazyConnection[T]{
+		Items: items,
+		PageInfo: PageInfo{
+			HasPreviousPage: hasPrev,
+			HasNextPage:     hasNext,
+		},
+		TotalCount: total,
+		cursorFn:   cursorFn,
+		cursors:    make([]string, len(items)),
+		computed:   make([]bool, len(items)),
+	}
+	if len(items) > 0 {
+		conn.PageInfo.StartCursor = conn.EdgeCursor(0)
+		conn.PageInfo.EndCursor = conn.EdgeCursor(len(items) - 1)
+	}
+	return conn
+}
+
+// Len returns the number of edges.
+func (c *LazyConnection[T]) Len() int {
+	return len(c.Items)
+}
+
+// EdgeCursor returns the cursor of the i-th edge, computing it on first
+// access. It panics if i is out of range.
+func (c *LazyConnection[T]) EdgeCursor(i int) string {
+	if !c.computed[i] {
+		c.cursors[i] = c.cursorFn(c.Items[i])
+		c.computed[i] = true
+	}
+	return c.cursors[i]
+}
+
+// Edge returns the i-th edge, computing its cursor on first access. It
+// panics if i is out of range.
+func (c *LazyConnection[T]) Edge(i int) Edge[T] {
+	return Edge[T]{Node: c.Items[i], Cursor: c.EdgeCursor(i)}
+}
+
+// ToConnection computes all remaining edge cursors and returns the
+// equivalent eager Connection.
+func (c *LazyConnection[T]) ToConnection() *Connection[T] {
+	edges := make([]Edge[T], len(c.Items))
+	for i := range c.Items {
+		edges[i] = c.Edge(i)
+	}
+	return &Connection[T]{
+		Edges:      edges,
+		PageInfo:   c.PageInfo,
+		TotalCount: c.TotalCount,
+	}
+}
+
+// MarshalJSON serializes the connection like Connection, computing every
+// edge cursor.
+func (c *LazyConnection[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.ToConnection())
+}
+
+// NewConnectionAuto creates a GraphQL-style connection, computing page info
+// with BuildPageInfo from the cursor arguments and the overfetch convention
+// (limit+1 items fetched):
+//   - Forward (first/after): hasNextPage is true if an extra item was fetched,
+//     which is trimmed from the end.
+//   - Backward (last/before): hasPreviousPage is true if an extra item was
+//     fetched, which is trimmed from the start.
+//
+// As in BuildPageInfo, the flag for the opposite direction is always false.
+// Items must be in display order in both directions.
+func NewConnectionAuto[T any](items []T, c *CursorPaginator, cursorFn func(T) string, total int64) *Connection[T] {
+	info := BuildPageInfo(c, len(items), true)
+
+	if DetectHasMore(items, c.Limit) {
+		if c.Forward {
+			items = items[:c.Limit]
+		} else {
+			items = items[len(items)-c.Limit:]
+		}
+	}
+	return NewConnection(items, cursorFn, info.HasPreviousPage, info.HasNextPage, total)
+}
+
+// BuildPageInfo computes the hasNextPage and hasPreviousPage flags strictly
+// following the Relay connection spec. edges is the number of rows fetched and
+// overfetched reports whether the query asked for Limit+1 rows.
+//   - Forward (first/after): hasNextPage is true if more than Limit rows were
+//     fetched; hasPreviousPage is false.
+//   - Backward (last/before): hasPreviousPage is true if more than Limit rows
+//     were fetched; hasNextPage is false.
+//
+// The flag for the opposite direction is always false, as the spec only
+// defines it when both first and last are given. Without overfetching the
+// presence of more rows cannot be determined, so both flags are false.
+// StartCursor and EndCursor are left empty.
+func BuildPageInfo(c *CursorPaginator, edges int, overfetched bool) PageInfo {
+	hasMore := overfetched && edges > c.Limit
+	if c.Forward {
+		return PageInfo{HasNextPage: hasMore}
+	}
+	return PageInfo{HasPreviousPage: hasMore}
+}
+
 // Empty returns true if the connection has no edges.
 func (c *Connection[T]) Empty() bool {
 	return len(c.Edges) == 0
@@ -160,8 +572,29 @@ func (c *Connection[T]) Count() int {
 	return len(c.Edges)
 }
 
+// ToCursorPage converts the connection to a REST-style cursor page, so one
+// data layer can serve both API surfaces. EndCursor becomes NextCursor when
+// there is a next page and StartCursor becomes PrevCursor when there is a
+// previous page; HasMore mirrors HasNextPage. The connection does not record
+// the requested limit, so Limit is inferred from the number of edges.
+func (c *Connection[T]) ToCursorPage() *CursorPage[T] {
+	page := &CursorPage[T]{
+		Items:   c.Nodes(),
+		HasMore: c.PageInfo.HasNextPage,
+		Limit:   len(c.Edges),
+	}
+	if c.PageInfo.HasNextPage {
+		page.NextCursor = c.PageInfo.EndCursor
+	}
+	if c.PageInfo.HasPreviousPage {
+		page.PrevCursor = c.PageInfo.StartCursor
+	}
+	return page
+}
+
 // LinkHeader represents pagination links for HTTP Link header (RFC 5988).
 type LinkHeader struct {
+	Self  string `json:"self,omitempty"`
 	First string `json:"first,omitempty"`
 	Prev  string `json:"prev,omitempty"`
 	Next  string `json:"next,omitempty"`
@@ -179,11 +612,11 @@ func BuildLinkHeader(baseURL string, p *Paginator, total int64) *LinkHeader {
 	header := &LinkHeader{}
 
 	// First page
-	first := p.WithPage(1)
+	first := p.WithPage(p.firstPage())
 	header.First = buildURL(baseURL, first.QueryParams())
 
 	// Last page
-	last := p.WithPage(totalPages)
+	last := p.WithPage(totalPages - 1 + p.firstPage())
 	header.Last = buildURL(baseURL, last.QueryParams())
 
 	// Previous page
@@ -201,6 +634,49 @@ func BuildLinkHeader(baseURL string, p *Paginator, total int64) *LinkHeader {
 	return header
 }
 
+// BuildLinkHeaderNoTotal builds pagination links for count-free APIs where
+// the total is unknown. First is always emitted, Prev when there is a
+// previous page and Next when hasNext is true; Last is omitted.
+func BuildLinkHeaderNoTotal(baseURL string, p *Paginator, hasNext bool) *LinkHeader {
+	header := &LinkHeader{
+		First: buildURL(baseURL, p.WithPage(p.firstPage()).QueryParams()),
+	}
+
+	if p.HasPrevious() {
+		header.Prev = buildURL(baseURL, p.WithPage(p.PreviousPage()).QueryParams())
+	}
+	if hasNext {
+		header.Next = buildURL(baseURL, p.WithPage(p.NextPage()).QueryParams())
+	}
+
+	return header
+}
+
+// BuildLinkHeaderWithSelf builds pagination links like BuildLinkHeader and
+// also includes the current page's URL as rel="self", for clients that
+// navigate purely via links.
+func BuildLinkHeaderWithSelf(baseURL string, p *Paginator, total int64) *LinkHeader {
+	header := BuildLinkHeader(baseURL, p, total)
+	header.Self = p.SelfURL(baseURL)
+	return header
+}
+
+// BuildCursorLinkHeader builds pagination links for a cursor-paginated page.
+// Next and Prev are derived from the page cursors; First and Last are omitted
+// because cursors cannot express them.
+func BuildCursorLinkHeader[T any](baseURL string, page *CursorPage[T], limit int) *LinkHeader {
+	header := &LinkHeader{}
+
+	if page.NextCursor != "" {
+		header.Next = buildURL(baseURL, page.NextQuery(limit))
+	}
+	if page.PrevCursor != "" {
+		header.Prev = buildURL(baseURL, page.PrevQuery(limit))
+	}
+
+	return header
+}
+
 // buildURL combines base URL with query parameters.
 func buildURL(baseURL string, params url.Values) string {
 	if len(params) == 0 {
@@ -214,6 +690,9 @@ func buildURL(baseURL string, params url.Values) string {
 func (h *LinkHeader) String() string {
 	var links []string
 
+	if h.Self != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="self"`, h.Self))
+	}
 	if h.First != "" {
 		links = append(links, fmt.Sprintf(`<%s>; rel="first"`, h.First))
 	}
@@ -1,6 +1,9 @@
 package paginate
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -34,6 +37,37 @@ func TestNewPage(t *testing.T) {
 	}
 }
 
+func TestNewPageAuto(t *testing.T) {
+	p := NewFromValues(2, 3)
+
+	counted := NewPageAuto([]int{1, 2, 3}, p, 10, false)
+	if counted.Total != 10 || counted.TotalPages != 4 || !counted.HasNext {
+		t.Errorf("Unexpected count-based page: %+v", counted)
+	}
+
+	overfetched := NewPageAuto([]int{1, 2, 3, 4}, p, 0, true)
+	if overfetched.Total != UnknownTotal {
+		t.Errorf("Expected unknown total, got %d", overfetched.Total)
+	}
+	if !overfetched.HasNext {
+		t.Error("Expected HasNext with an extra row")
+	}
+	if overfetched.Count() != 3 {
+		t.Errorf("Expected extra row to be trimmed, got %d items", overfetched.Count())
+	}
+	if !overfetched.HasPrev {
+		t.Error("Expected HasPrev on page 2")
+	}
+
+	last := NewPageAuto([]int{1, 2, 3}, p, 0, true)
+	if last.HasNext {
+		t.Error("Expected no HasNext without an extra row")
+	}
+	if last.Count() != 3 {
+		t.Errorf("Expected 3 items, got %d", last.Count())
+	}
+}
+
 func TestPageEmpty(t *testing.T) {
 	emptyPage := NewPage([]string{}, 0, New())
 	if !emptyPage.Empty() {
@@ -46,6 +80,283 @@ func TestPageEmpty(t *testing.T) {
 	}
 }
 
+func TestPageWriteHeadersWithNames(t *testing.T) {
+	page := NewPage([]string{"c", "d"}, 5, NewFromValues(2, 2))
+
+	tests := []struct {
+		name     string
+		names    HeaderNames
+		expected map[string]string
+	}{
+		{
+			"Defaults",
+			DefaultHeaderNames,
+			map[string]string{"X-Total-Count": "5", "X-Total-Pages": "3", "Content-Range": "items 2-3/5"},
+		},
+		{
+			"Custom total only",
+			HeaderNames{Total: "X-Record-Count"},
+			map[string]string{"X-Record-Count": "5"},
+		},
+		{
+			"Content-Range only",
+			HeaderNames{ContentRange: "Content-Range"},
+			map[string]string{"Content-Range": "items 2-3/5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			page.WriteHeadersWithNames(func(k, v string) { got[k] = v }, tt.names)
+
+			if len(got) != len(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			for k, v := range tt.expected {
+				if got[k] != v {
+					t.Errorf("Expected %s=%s, got %s", k, v, got[k])
+				}
+			}
+		})
+	}
+}
+
+func TestPageWriteHeadersUnknownTotal(t *testing.T) {
+	page := NewPage([]string{"a", "b"}, UnknownTotal, NewFromValues(1, 2))
+
+	got := map[string]string{}
+	page.WriteHeaders(func(k, v string) { got[k] = v })
+
+	if _, ok := got["X-Total-Count"]; ok {
+		t.Error("Expected no total header for unknown total")
+	}
+	if got["Content-Range"] != "items 0-1/*" {
+		t.Errorf("Expected 'items 0-1/*', got %q", got["Content-Range"])
+	}
+}
+
+func TestPageReduce(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+
+	page := NewPage([]int{1, 2, 3}, 3, New())
+	if got := PageReduce(page, 0, sum); got != 6 {
+		t.Errorf("Expected 6, got %d", got)
+	}
+
+	concat := PageReduce(NewPage([]string{"a", "b"}, 2, New()), "", func(acc, v string) string { return acc + v })
+	if concat != "ab" {
+		t.Errorf("Expected ab, got %s", concat)
+	}
+
+	if got := PageReduce(NewPage([]int{}, 0, New()), 42, sum); got != 42 {
+		t.Errorf("Expected init for empty page, got %d", got)
+	}
+	if got := PageReduce[int](nil, 42, sum); got != 42 {
+		t.Errorf("Expected init for nil page, got %d", got)
+	}
+}
+
+func TestPageIsFirstIsLast(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    *Page[int]
+		isFirst bool
+		isLast  bool
+	}{
+		{"Single page", NewPage([]int{1, 2}, 2, New()), true, true},
+		{"First of many", NewPage([]int{1, 2}, 100, New().WithPageSize(2)), true, false},
+		{"Last of many", NewPage([]int{1, 2}, 10, New().WithPage(5).WithPageSize(2)), false, true},
+		{"Overfetched with more", NewPageAuto([]int{1, 2, 3}, New().WithPage(2).WithPageSize(2), 0, true), false, false},
+		{"Overfetched at end", NewPageAuto([]int{1}, New().WithPage(2).WithPageSize(2), 0, true), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.page.IsFirst() != tt.isFirst {
+				t.Errorf("Expected IsFirst=%v, got %v", tt.isFirst, tt.page.IsFirst())
+			}
+			if tt.page.IsLast() != tt.isLast {
+				t.Errorf("Expected IsLast=%v, got %v", tt.isLast, tt.page.IsLast())
+			}
+		})
+	}
+}
+
+func TestUnknownTotal(t *testing.T) {
+	p := NewFromValues(2, 3)
+
+	full := NewPage([]string{"a", "b", "c"}, UnknownTotal, p)
+	if full.TotalPages != 0 {
+		t.Errorf("Expected 0 total pages, got %d", full.TotalPages)
+	}
+	if !full.HasNext {
+		t.Error("Expected HasNext for a full page with unknown total")
+	}
+	if !full.HasPrev {
+		t.Error("Expected HasPrev on page 2")
+	}
+
+	partial := NewPage([]string{"a"}, UnknownTotal, p)
+	if partial.HasNext {
+		t.Error("Expected no HasNext for a partial page with unknown total")
+	}
+
+	rr := NewRangeResponse([]string{"a", "b"}, NewRange(10, 11), UnknownTotal)
+	if !rr.HasMore() {
+		t.Error("Expected HasMore for filled range with unknown total")
+	}
+	if cr := rr.ContentRange(); cr != "items 10-11/*" {
+		t.Errorf("Expected 'items 10-11/*', got '%s'", cr)
+	}
+
+	short := NewRangeResponse([]string{"a", "b"}, NewRange(10, 19), UnknownTotal)
+	if short.HasMore() {
+		t.Error("Expected no HasMore for short range with unknown total")
+	}
+
+	emptyRange := NewRangeResponse([]string{}, NewRange(10, 19), UnknownTotal)
+	if emptyRange.HasMore() {
+		t.Error("Expected no HasMore for empty range with unknown total")
+	}
+	if cr := emptyRange.ContentRange(); cr != "items */*" {
+		t.Errorf("Expected 'items */*', got '%s'", cr)
+	}
+
+	conn := NewConnection([]string{"a"}, func(s string) string { return s }, false, true, UnknownTotal)
+	if conn.TotalCount != UnknownTotal {
+		t.Errorf("Expected total count %d, got %d", UnknownTotal, conn.TotalCount)
+	}
+	if !conn.PageInfo.HasNextPage {
+		t.Error("Expected HasNextPage to be preserved")
+	}
+}
+
+func TestPageViewMarshalJSON(t *testing.T) {
+	p := NewFromValues(1, 2)
+	items := []string{"a", "b"}
+
+	tests := []struct {
+		name     string
+		page     any
+		expected string
+	}{
+		{
+			"Default",
+			NewPage(items, 5, p),
+			`{"items":["a","b"],"total":5,"page":1,"page_size":2,"total_pages":3,"has_prev":false,"has_next":true}`,
+		},
+		{
+			"Zero values kept",
+			NewPage([]string{}, 0, p).View(PageOptions{}),
+			`{"items":[],"total":0,"page":1,"page_size":2,"total_pages":0,"has_prev":false,"has_next":false}`,
+		},
+		{
+			"Unknown total",
+			NewPageWithOptions(items, UnknownTotal, p, PageOptions{}),
+			`{"items":["a","b"],"page":1,"page_size":2,"has_prev":false,"has_next":true}`,
+		},
+		{
+			"Overfetched",
+			NewPageAuto([]string{"a", "b", "c"}, p, 0, true).View(PageOptions{}),
+			`{"items":["a","b"],"page":1,"page_size":2,"has_prev":false,"has_next":true}`,
+		},
+		{
+			"Omit navigation",
+			NewPageWithOptions(items, 5, p, PageOptions{OmitNavigation: true}),
+			`{"items":["a","b"],"total":5,"page":1,"page_size":2,"total_pages":3}`,
+		},
+		{
+			"Embedded page keeps wrapper fields",
+			struct {
+				Page[string]
+				RequestID string `json:"request_id"`
+			}{*NewPage(items, 5, p), "r1"},
+			`{"items":["a","b"],"total":5,"page":1,"page_size":2,"total_pages":3,"has_prev":false,"has_next":true,"request_id":"r1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.page)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(b) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, b)
+			}
+		})
+	}
+}
+
+func TestNewPageWithLinks(t *testing.T) {
+	baseURL := "https://api.example.com/users"
+	p := NewFromValues(2, 2)
+
+	page := NewPageWithLinks([]string{"c", "d"}, 6, p, baseURL)
+	if page.Links == nil {
+		t.Fatal("Expected links")
+	}
+	if page.Links.Next != baseURL+"?page=3&page_size=2" || page.Links.Last != baseURL+"?page=3&page_size=2" {
+		t.Errorf("Unexpected links: %+v", page.Links)
+	}
+
+	b, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !contains(string(b), `"links":{"first":`) {
+		t.Errorf("Expected links in body, got %s", b)
+	}
+
+	unknown := NewPageWithLinks([]string{"c", "d"}, UnknownTotal, p, baseURL)
+	if unknown.Links.Last != "" || unknown.Links.Next == "" {
+		t.Errorf("Expected Next without Last for unknown total, got %+v", unknown.Links)
+	}
+
+	b, _ = json.Marshal(NewPage([]string{"a"}, 1, p))
+	if contains(string(b), "links") {
+		t.Errorf("Expected links to be omitted by default, got %s", b)
+	}
+}
+
+func TestNewCursorPageWithLinks(t *testing.T) {
+	baseURL := "https://api.example.com/users"
+	page := NewCursorPageWithLinks([]string{"a"}, 10, "next", "prev", true, baseURL)
+
+	if page.Links == nil {
+		t.Fatal("Expected links")
+	}
+	if page.Links.Next != baseURL+"?after=next&limit=10" || page.Links.Prev != baseURL+"?before=prev&limit=10" {
+		t.Errorf("Unexpected links: %+v", page.Links)
+	}
+
+	b, _ := json.Marshal(page)
+	if !contains(string(b), `"links":{"prev":`) {
+		t.Errorf("Expected links in body, got %s", b)
+	}
+}
+
+func TestPageWithMeta(t *testing.T) {
+	page := NewPage([]string{"a"}, 1, NewFromValues(1, 10))
+	withMeta := page.WithMeta(map[string]any{"fields": "id,name"}).
+		WithMeta(map[string]any{"filter": "active", "total": "ignored"})
+
+	if page.Meta != nil {
+		t.Error("WithMeta should not modify the original page")
+	}
+
+	b, err := json.Marshal(withMeta)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"items":["a"],"total":1,"page":1,"page_size":10,"total_pages":1,"has_prev":false,"has_next":false,` +
+		`"meta":{"fields":"id,name","filter":"active","total":"ignored"}}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+}
+
 func TestNewCursorPage(t *testing.T) {
 	items := []int{1, 2, 3}
 	nextCursor := "next-cursor"
@@ -90,6 +401,51 @@ func TestNewCursorPageSimple(t *testing.T) {
 	}
 }
 
+func TestNewEmptyCursorPage(t *testing.T) {
+	page := NewEmptyCursorPage[int](20)
+
+	if page.HasMore || page.NextCursor != "" || page.PrevCursor != "" {
+		t.Errorf("Expected no further pages, got %+v", page)
+	}
+	if page.Limit != 20 {
+		t.Errorf("Expected limit 20, got %d", page.Limit)
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"items":[]`) {
+		t.Errorf("Expected empty items array, got %s", data)
+	}
+}
+
+func TestCursorPageWithTotalCount(t *testing.T) {
+	page := NewCursorPageSimple([]int{1, 2}, 2, "next")
+
+	first := page.WithTotalCount(10, NewCursorWithLimit(2))
+	if first.TotalCount == nil || *first.TotalCount != 10 {
+		t.Errorf("Expected total count 10 on first page, got %v", first.TotalCount)
+	}
+	if page.TotalCount != nil {
+		t.Error("Original page should not be modified")
+	}
+
+	later := first.WithTotalCount(10, NewCursorWithLimit(2).WithCursor("abc"))
+	if later.TotalCount != nil {
+		t.Errorf("Expected no total count on later pages, got %d", *later.TotalCount)
+	}
+
+	data, _ := json.Marshal(later)
+	if contains(string(data), "total_count") {
+		t.Errorf("Expected total_count to be omitted, got %s", data)
+	}
+	data, _ = json.Marshal(NewCursorPageSimple([]int{}, 2, "").WithTotalCount(0, NewCursor()))
+	if !contains(string(data), `"total_count":0`) {
+		t.Errorf("Expected zero total_count on first page, got %s", data)
+	}
+}
+
 func TestCursorPageEmpty(t *testing.T) {
 	emptyPage := NewCursorPageSimple([]int{}, 10, "")
 	if !emptyPage.Empty() {
@@ -102,6 +458,99 @@ type testItem struct {
 	Name string
 }
 
+func TestCursors(t *testing.T) {
+	cursorFn := func(item testItem) string { return "c" + item.ID }
+
+	first, last := Cursors([]testItem{{ID: "1"}, {ID: "2"}, {ID: "3"}}, cursorFn)
+	if first != "c1" || last != "c3" {
+		t.Errorf("Expected (c1, c3), got (%s, %s)", first, last)
+	}
+
+	first, last = Cursors([]testItem{{ID: "1"}}, cursorFn)
+	if first != "c1" || last != "c1" {
+		t.Errorf("Expected (c1, c1), got (%s, %s)", first, last)
+	}
+
+	first, last = Cursors(nil, cursorFn)
+	if first != "" || last != "" {
+		t.Errorf("Expected empty cursors, got (%s, %s)", first, last)
+	}
+}
+
+func TestDetectHasMore(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []int
+		limit    int
+		expected bool
+	}{
+		{"Overfetched", []int{1, 2, 3, 4}, 3, true},
+		{"Exact", []int{1, 2, 3}, 3, false},
+		{"Short", []int{1}, 3, false},
+		{"Empty", nil, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectHasMore(tt.items, tt.limit); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTrimForHasMore(t *testing.T) {
+	items, hasMore := TrimForHasMore([]int{1, 2, 3, 4}, 3)
+	if !hasMore {
+		t.Error("Expected hasMore to be true")
+	}
+	if len(items) != 3 {
+		t.Errorf("Expected 3 items, got %d", len(items))
+	}
+
+	items, hasMore = TrimForHasMore([]int{1, 2}, 3)
+	if hasMore {
+		t.Error("Expected hasMore to be false")
+	}
+	if len(items) != 2 {
+		t.Errorf("Expected 2 items, got %d", len(items))
+	}
+}
+
+func TestCursorPageQueries(t *testing.T) {
+	page := NewCursorPage([]int{1, 2, 3}, 10, "next-cursor", "prev-cursor", true)
+
+	next := page.NextQuery(10)
+	if next.Get("after") != "next-cursor" {
+		t.Errorf("Expected after 'next-cursor', got '%s'", next.Get("after"))
+	}
+	if next.Has("before") {
+		t.Error("Next query should not contain before")
+	}
+	if next.Get("limit") != "10" {
+		t.Errorf("Expected limit '10', got '%s'", next.Get("limit"))
+	}
+
+	prev := page.PrevQuery(10)
+	if prev.Get("before") != "prev-cursor" {
+		t.Errorf("Expected before 'prev-cursor', got '%s'", prev.Get("before"))
+	}
+	if prev.Has("after") {
+		t.Error("Prev query should not contain after")
+	}
+	if prev.Get("limit") != "10" {
+		t.Errorf("Expected limit '10', got '%s'", prev.Get("limit"))
+	}
+
+	empty := NewCursorPage([]int{}, 10, "", "", false)
+	if len(empty.NextQuery(10)) != 0 {
+		t.Error("Expected empty next query without next cursor")
+	}
+	if len(empty.PrevQuery(10)) != 0 {
+		t.Error("Expected empty prev query without prev cursor")
+	}
+}
+
 func TestNewConnection(t *testing.T) {
 	items := []testItem{
 		{ID: "1", Name: "First"},
@@ -149,6 +598,123 @@ func TestNewConnection(t *testing.T) {
 	}
 }
 
+func TestNewConnectionLazy(t *testing.T) {
+	items := []testItem{
+		{ID: "1", Name: "First"},
+		{ID: "2", Name: "Second"},
+		{ID: "3", Name: "Third"},
+		{ID: "4", Name: "Fourth"},
+	}
+	calls := 0
+	cursorFn := func(item testItem) string {
+		calls++
+		return "cursor_" + item.ID
+	}
+
+	conn := NewConnectionLazy(items, cursorFn, false, true, 10)
+	if calls != 2 {
+		t.Errorf("Expected 2 eager cursor calls, got %d", calls)
+	}
+	if conn.PageInfo.StartCursor != "cursor_1" || conn.PageInfo.EndCursor != "cursor_4" {
+		t.Errorf("Unexpected page info: %+v", conn.PageInfo)
+	}
+
+	if edge := conn.Edge(1); edge.Cursor != "cursor_2" || edge.Node.ID != "2" {
+		t.Errorf("Unexpected edge: %+v", edge)
+	}
+	conn.EdgeCursor(1)
+	if calls != 3 {
+		t.Errorf("Expected cursors to be cached, got %d calls", calls)
+	}
+
+	lazy, err := json.Marshal(conn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	eager, _ := json.Marshal(NewConnection(items, cursorFn, false, true, 10))
+	if string(lazy) != string(eager) {
+		t.Errorf("Expected %s, got %s", eager, lazy)
+	}
+
+	empty := NewConnectionLazy([]testItem{}, cursorFn, false, false, 0)
+	if empty.Len() != 0 || empty.PageInfo.StartCursor != "" {
+		t.Errorf("Unexpected empty connection: %+v", empty.PageInfo)
+	}
+}
+
+func TestNewConnectionAuto(t *testing.T) {
+	cursorFn := func(n int) string { return strconv.Itoa(n) }
+
+	tests := []struct {
+		name          string
+		items         []int
+		paginator     *CursorPaginator
+		expectedNodes []int
+		expectedPrev  bool
+		expectedNext  bool
+	}{
+		{"Forward first page with more", []int{1, 2, 3, 4}, NewCursorWithLimit(3), []int{1, 2, 3}, false, true},
+		{"Forward after cursor, last page", []int{4, 5}, NewCursorWithLimit(3).WithCursor("3"), []int{4, 5}, false, false},
+		{"Backward with more", []int{1, 2, 3, 4}, NewCursorWithLimit(3).WithForward(false).WithCursor("5"), []int{2, 3, 4}, true, false},
+		{"Backward without cursor", []int{1, 2}, NewCursorWithLimit(3).WithForward(false), []int{1, 2}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := NewConnectionAuto(tt.items, tt.paginator, cursorFn, 10)
+
+			nodes := conn.Nodes()
+			if len(nodes) != len(tt.expectedNodes) {
+				t.Fatalf("Expected nodes %v, got %v", tt.expectedNodes, nodes)
+			}
+			for i := range nodes {
+				if nodes[i] != tt.expectedNodes[i] {
+					t.Fatalf("Expected nodes %v, got %v", tt.expectedNodes, nodes)
+				}
+			}
+			if conn.PageInfo.HasPreviousPage != tt.expectedPrev {
+				t.Errorf("Expected HasPreviousPage=%v, got %v", tt.expectedPrev, conn.PageInfo.HasPreviousPage)
+			}
+			if conn.PageInfo.HasNextPage != tt.expectedNext {
+				t.Errorf("Expected HasNextPage=%v, got %v", tt.expectedNext, conn.PageInfo.HasNextPage)
+			}
+			if conn.PageInfo.StartCursor != cursorFn(tt.expectedNodes[0]) {
+				t.Errorf("Unexpected start cursor %s", conn.PageInfo.StartCursor)
+			}
+			if conn.PageInfo.EndCursor != cursorFn(tt.expectedNodes[len(tt.expectedNodes)-1]) {
+				t.Errorf("Unexpected end cursor %s", conn.PageInfo.EndCursor)
+			}
+		})
+	}
+}
+
+func TestBuildPageInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		c           *CursorPaginator
+		edges       int
+		overfetched bool
+		hasPrev     bool
+		hasNext     bool
+	}{
+		{"Forward with more", NewCursorWithLimit(2), 3, true, false, true},
+		{"Forward at end", NewCursorWithLimit(2), 2, true, false, false},
+		{"Forward after cursor", NewCursorWithLimit(2).WithCursor("abc"), 3, true, false, true},
+		{"Backward with more", NewCursorWithLimit(2).WithForward(false).WithCursor("abc"), 3, true, true, false},
+		{"Backward at start", NewCursorWithLimit(2).WithForward(false), 1, true, false, false},
+		{"Not overfetched", NewCursorWithLimit(2), 3, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := BuildPageInfo(tt.c, tt.edges, tt.overfetched)
+			if info.HasPreviousPage != tt.hasPrev || info.HasNextPage != tt.hasNext {
+				t.Errorf("Expected prev=%v next=%v, got %+v", tt.hasPrev, tt.hasNext, info)
+			}
+		})
+	}
+}
+
 func TestConnectionEmpty(t *testing.T) {
 	conn := NewConnection([]testItem{}, func(item testItem) string {
 		return item.ID
@@ -162,6 +728,79 @@ func TestConnectionEmpty(t *testing.T) {
 	}
 }
 
+func TestConnectionToCursorPage(t *testing.T) {
+	items := []testItem{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	cursorFn := func(item testItem) string { return "c" + item.ID }
+
+	tests := []struct {
+		name    string
+		hasPrev bool
+		hasNext bool
+		prev    string
+		next    string
+	}{
+		{"Middle page", true, true, "c1", "c3"},
+		{"First page", false, true, "", "c3"},
+		{"Last page", true, false, "c1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewConnection(items, cursorFn, tt.hasPrev, tt.hasNext, UnknownTotal).ToCursorPage()
+
+			if len(page.Items) != 3 || page.Items[0].ID != "1" {
+				t.Errorf("Unexpected items: %v", page.Items)
+			}
+			if page.PrevCursor != tt.prev || page.NextCursor != tt.next {
+				t.Errorf("Expected prev=%q next=%q, got prev=%q next=%q",
+					tt.prev, tt.next, page.PrevCursor, page.NextCursor)
+			}
+			if page.HasMore != tt.hasNext {
+				t.Errorf("Expected HasMore=%v, got %v", tt.hasNext, page.HasMore)
+			}
+			if page.Limit != 3 {
+				t.Errorf("Expected limit 3, got %d", page.Limit)
+			}
+		})
+	}
+}
+
+func TestCursorPageToConnection(t *testing.T) {
+	items := []testItem{{ID: "1"}, {ID: "2"}}
+	cursorFn := func(item testItem) string { return "c" + item.ID }
+
+	tests := []struct {
+		name    string
+		page    *CursorPage[testItem]
+		hasPrev bool
+		prev    bool
+		next    bool
+	}{
+		{"First page", NewCursorPageSimple(items, 2, "c2"), false, false, true},
+		{"Middle page", NewCursorPage(items, 2, "c2", "c1", true), false, true, true},
+		{"Explicit prev", NewCursorPageSimple(items, 2, ""), true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := tt.page.ToConnection(cursorFn, tt.hasPrev, 10)
+
+			if len(conn.Edges) != 2 || conn.Edges[1].Cursor != "c2" {
+				t.Errorf("Unexpected edges: %v", conn.Edges)
+			}
+			if conn.PageInfo.StartCursor != "c1" || conn.PageInfo.EndCursor != "c2" {
+				t.Errorf("Unexpected cursors: %+v", conn.PageInfo)
+			}
+			if conn.PageInfo.HasPreviousPage != tt.prev || conn.PageInfo.HasNextPage != tt.next {
+				t.Errorf("Expected prev=%v next=%v, got %+v", tt.prev, tt.next, conn.PageInfo)
+			}
+			if conn.TotalCount != 10 {
+				t.Errorf("Expected total 10, got %d", conn.TotalCount)
+			}
+		})
+	}
+}
+
 func TestConnectionNodes(t *testing.T) {
 	items := []testItem{
 		{ID: "1", Name: "First"},
@@ -206,6 +845,67 @@ func TestBuildLinkHeader(t *testing.T) {
 	}
 }
 
+func TestBuildLinkHeaderNoTotal(t *testing.T) {
+	baseURL := "https://api.example.com/users"
+
+	tests := []struct {
+		name    string
+		page    int
+		hasNext bool
+		prev    bool
+		next    bool
+	}{
+		{"First page with more", 1, true, false, true},
+		{"Middle page", 3, true, true, true},
+		{"Last page", 3, false, true, false},
+		{"Only page", 1, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := BuildLinkHeaderNoTotal(baseURL, NewFromValues(tt.page, 20), tt.hasNext)
+
+			if links.First != baseURL+"?page=1&page_size=20" {
+				t.Errorf("Unexpected First link: %s", links.First)
+			}
+			if links.Last != "" {
+				t.Errorf("Expected no Last link, got %s", links.Last)
+			}
+			if (links.Prev != "") != tt.prev {
+				t.Errorf("Expected Prev=%v, got %q", tt.prev, links.Prev)
+			}
+			if (links.Next != "") != tt.next {
+				t.Errorf("Expected Next=%v, got %q", tt.next, links.Next)
+			}
+		})
+	}
+}
+
+func TestBuildLinkHeaderWithSelf(t *testing.T) {
+	p := NewFromValues(3, 20)
+	baseURL := "https://api.example.com/users"
+
+	self := p.SelfURL(baseURL)
+	if self != baseURL+"?page=3&page_size=20" {
+		t.Errorf("Unexpected self URL: %s", self)
+	}
+
+	links := BuildLinkHeaderWithSelf(baseURL, p, 100)
+	if links.Self != self {
+		t.Errorf("Expected Self %s, got %s", self, links.Self)
+	}
+	if links.Next == "" || links.Prev == "" {
+		t.Error("Expected navigation links alongside self")
+	}
+	if !contains(links.String(), `<`+self+`>; rel="self"`) {
+		t.Errorf("Expected self rel in %s", links.String())
+	}
+
+	if BuildLinkHeader(baseURL, p, 100).Self != "" {
+		t.Error("BuildLinkHeader should not include self")
+	}
+}
+
 func TestBuildLinkHeaderEdgeCases(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -239,6 +939,31 @@ func TestBuildLinkHeaderEdgeCases(t *testing.T) {
 	}
 }
 
+func TestBuildCursorLinkHeader(t *testing.T) {
+	page := NewCursorPage([]int{1, 2}, 10, "next", "prev", true)
+	header := BuildCursorLinkHeader("http://api.example.com/items", page, 10)
+
+	if header.Next != "http://api.example.com/items?after=next&limit=10" {
+		t.Errorf("Unexpected next link: %s", header.Next)
+	}
+	if header.Prev != "http://api.example.com/items?before=prev&limit=10" {
+		t.Errorf("Unexpected prev link: %s", header.Prev)
+	}
+	if header.First != "" || header.Last != "" {
+		t.Error("Cursor link header should not set first or last")
+	}
+
+	str := header.String()
+	if !contains(str, `rel="next"`) || !contains(str, `rel="prev"`) {
+		t.Errorf("Unexpected link header string: %s", str)
+	}
+
+	empty := BuildCursorLinkHeader("http://api.example.com/items", NewCursorPage([]int{}, 10, "", "", false), 10)
+	if empty.String() != "" {
+		t.Errorf("Expected empty link header, got %s", empty.String())
+	}
+}
+
 func TestLinkHeaderString(t *testing.T) {
 	links := &LinkHeader{
 		First: "https://example.com?page=1",
@@ -0,0 +1,102 @@
+package paginate
+
+import "encoding/json"
+
+// Kind identifies the pagination strategy of a Result.
+type Kind int
+
+// Pagination strategies supported by Result.
+const (
+	KindPage Kind = iota
+	KindCursor
+	KindConnection
+	KindRange
+)
+
+// String returns the name of the pagination strategy.
+func (k Kind) String() string {
+	switch k {
+	case KindPage:
+		return "page"
+	case KindCursor:
+		return "cursor"
+	case KindConnection:
+		return "connection"
+	case KindRange:
+		return "range"
+	default:
+		return "unknown"
+	}
+}
+
+// Result wraps a paginated response of any strategy so generic handlers and
+// middleware can work with a single return type. Only the field matching
+// Kind is populated.
+type Result[T any] struct {
+	Kind       Kind
+	Page       *Page[T]
+	CursorPage *CursorPage[T]
+	Connection *Connection[T]
+	Range      *RangeResponse[T]
+}
+
+// NewPageResult wraps an offset-paginated page.
+func NewPageResult[T any](page *Page[T]) *Result[T] {
+	return &Result[T]{Kind: KindPage, Page: page}
+}
+
+// NewCursorResult wraps a cursor-paginated page.
+func NewCursorResult[T any](page *CursorPage[T]) *Result[T] {
+	return &Result[T]{Kind: KindCursor, CursorPage: page}
+}
+
+// NewConnectionResult wraps a GraphQL-style connection.
+func NewConnectionResult[T any](conn *Connection[T]) *Result[T] {
+	return &Result[T]{Kind: KindConnection, Connection: conn}
+}
+
+// NewRangeResult wraps a range-based response.
+func NewRangeResult[T any](resp *RangeResponse[T]) *Result[T] {
+	return &Result[T]{Kind: KindRange, Range: resp}
+}
+
+// Items returns the items of the wrapped response, regardless of strategy.
+// Returns nil if the response for Kind is not set.
+func (r *Result[T]) Items() []T {
+	switch r.Kind {
+	case KindPage:
+		if r.Page != nil {
+			return r.Page.Items
+		}
+	case KindCursor:
+		if r.CursorPage != nil {
+			return r.CursorPage.Items
+		}
+	case KindConnection:
+		if r.Connection != nil {
+			return r.Connection.Nodes()
+		}
+	case KindRange:
+		if r.Range != nil {
+			return r.Range.Items
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes only the wrapped response matching Kind, so a Result
+// serializes exactly like the underlying response.
+func (r *Result[T]) MarshalJSON() ([]byte, error) {
+	switch r.Kind {
+	case KindPage:
+		return json.Marshal(r.Page)
+	case KindCursor:
+		return json.Marshal(r.CursorPage)
+	case KindConnection:
+		return json.Marshal(r.Connection)
+	case KindRange:
+		return json.Marshal(r.Range)
+	default:
+		return []byte("null"), nil
+	}
+}
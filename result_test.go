@@ -0,0 +1,88 @@
+package paginate
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestResultItems(t *testing.T) {
+	items := []int{1, 2, 3}
+	conn := NewConnection(items, strconv.Itoa, false, true, 10)
+
+	tests := []struct {
+		name   string
+		result *Result[int]
+		kind   Kind
+	}{
+		{"Page", NewPageResult(NewPage(items, 10, New())), KindPage},
+		{"Cursor", NewCursorResult(NewCursorPageSimple(items, 3, "next")), KindCursor},
+		{"Connection", NewConnectionResult(conn), KindConnection},
+		{"Range", NewRangeResult(NewRangeResponse(items, NewRange(0, 2), 10)), KindRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result.Kind != tt.kind {
+				t.Errorf("Expected kind %v, got %v", tt.kind, tt.result.Kind)
+			}
+			got := tt.result.Items()
+			if len(got) != len(items) {
+				t.Fatalf("Expected %d items, got %d", len(items), len(got))
+			}
+			for i := range items {
+				if got[i] != items[i] {
+					t.Errorf("Expected item %d at %d, got %d", items[i], i, got[i])
+				}
+			}
+		})
+	}
+
+	empty := &Result[int]{Kind: KindCursor}
+	if empty.Items() != nil {
+		t.Error("Expected nil items for unset response")
+	}
+}
+
+func TestResultMarshalJSON(t *testing.T) {
+	page := NewCursorPageSimple([]string{"a"}, 1, "next")
+	want, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := json.Marshal(NewCursorResult(page))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	unknown, err := json.Marshal(&Result[string]{Kind: Kind(99)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(unknown) != "null" {
+		t.Errorf("Expected null, got %s", unknown)
+	}
+}
+
+func TestKindString(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		expected string
+	}{
+		{KindPage, "page"},
+		{KindCursor, "cursor"},
+		{KindConnection, "connection"},
+		{KindRange, "range"},
+		{Kind(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if s := tt.kind.String(); s != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, s)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package paginate
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// SecureCursorOptions selects the transforms EncodeSecureCursor applies to a
+// cursor payload. When decoding, Sign and Encrypt are requirements: cursors
+// lacking a required transform are rejected, so a client cannot strip the
+// signature by rewriting the header.
+type SecureCursorOptions struct {
+	// Compress deflates the payload, useful for cursors with large Values.
+	Compress bool
+
+	// Sign appends an HMAC-SHA256 of the cursor, so tampering is detected.
+	Sign bool
+
+	// Encrypt seals the payload with AES-256-GCM, hiding its contents from
+	// clients. It also authenticates the payload, but combine it with Sign
+	// if the header must be verified before decryption.
+	Encrypt bool
+}
+
+// Flags of the secure cursor header byte. The high nibble identifies the
+// header version; the low bits record which transforms were applied. Format
+// markers 0x90-0x9f are reserved for it and cannot be registered as codecs.
+const (
+	secureCursorVersion byte = 0x90
+	secureVersionMask   byte = 0xf0
+	secureFlagCompress  byte = 1 << 0
+	secureFlagSign      byte = 1 << 1
+	secureFlagEncrypt   byte = 1 << 2
+)
+
+// maxSecurePayload bounds the decompressed payload of a secure cursor, so
+// crafted cursors cannot inflate to arbitrary sizes.
+const maxSecurePayload = 64 << 10
+
+// EncodeSecureCursor encodes cursor data like EncodeCursor and applies the
+// transforms selected by opts in a canonical order: the payload is
+// compressed, then encrypted, then the result (including the header) is
+// signed. A single header byte records the transforms, so DecodeSecureCursor
+// reverses them automatically.
+//
+// key is required for signing or encryption, else ErrSecureCursorKey is
+// returned; separate subkeys are derived from it for each, so one secret of
+// any length can be used for both. Secure cursors are not understood by
+// DecodeCursor; use CursorPaginator.WithSecureKey to page with them.
+// Returns an empty string and nil error if data is nil.
+func EncodeSecureCursor[T any](data *CursorData[T], key []byte, opts SecureCursorOptions) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+	if (opts.Sign || opts.Encrypt) && len(key) == 0 {
+		return "", ErrSecureCursorKey
+	}
+
+	normalized := normalizeCursor(data, 0)
+	body, err := marshalCursor(&normalized)
+	if err != nil {
+		return "", err
+	}
+
+	header := secureCursorVersion
+	if opts.Compress {
+		header |= secureFlagCompress
+		if body, err = deflatePayload(body); err != nil {
+			return "", err
+		}
+	}
+	if opts.Encrypt {
+		header |= secureFlagEncrypt
+	}
+	if opts.Sign {
+		header |= secureFlagSign
+	}
+	if opts.Encrypt {
+		if body, err = sealPayload(key, header, body); err != nil {
+			return "", err
+		}
+	}
+
+	b := append([]byte{header}, body...)
+	if opts.Sign {
+		b = append(b, signPayload(key, b)...)
+	}
+	return checkCursorSize(EncodeCursorRaw(b))
+}
+
+// DecodeSecureCursor decodes a cursor produced by EncodeSecureCursor,
+// verifying the signature, decrypting and decompressing as recorded in its
+// header. Returns nil if the cursor is empty, or ErrInvalidCursor if it is
+// malformed, fails verification or decryption, or lacks a transform
+// required by opts.
+func DecodeSecureCursor[T any](cursor string, key []byte, opts SecureCursorOptions) (*CursorData[T], error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	b, err := DecodeCursorRaw(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 || b[0]&secureVersionMask != secureCursorVersion {
+		return nil, fmt.Errorf("%w: not a secure cursor", ErrInvalidCursor)
+	}
+	header := b[0]
+
+	if opts.Sign && header&secureFlagSign == 0 {
+		return nil, fmt.Errorf("%w: cursor is not signed", ErrInvalidCursor)
+	}
+	if opts.Encrypt && header&secureFlagEncrypt == 0 {
+		return nil, fmt.Errorf("%w: cursor is not encrypted", ErrInvalidCursor)
+	}
+	if header&(secureFlagSign|secureFlagEncrypt) != 0 && len(key) == 0 {
+		return nil, ErrSecureCursorKey
+	}
+
+	if header&secureFlagSign != 0 {
+		if len(b) < 1+sha256.Size {
+			return nil, fmt.Errorf("%w: truncated signature", ErrInvalidCursor)
+		}
+		signed, mac := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+		if !hmac.Equal(mac, signPayload(key, signed)) {
+			return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+		}
+		b = signed
+	}
+
+	body := b[1:]
+	if header&secureFlagEncrypt != 0 {
+		if body, err = openPayload(key, header, body); err != nil {
+			return nil, err
+		}
+	}
+	if header&secureFlagCompress != 0 {
+		if body, err = inflatePayload(body); err != nil {
+			return nil, err
+		}
+	}
+
+	var data CursorData[T]
+	if err := unmarshalCursor(body, &data); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &data, nil
+}
+
+// CursorFeatures describes the transforms applied to a cursor, as recorded
+// in its format header.
+type CursorFeatures struct {
+	Compressed bool `json:"compressed"`
+	Signed     bool `json:"signed"`
+	Encrypted  bool `json:"encrypted"`
+
+	// Version is the secure cursor header version, or 0 for plain cursors
+	// produced by EncodeCursor.
+	Version int `json:"version"`
+}
+
+// InspectCursor reports which transforms a cursor uses by reading its format
+// header, without verifying or decoding the payload. Use it to migrate
+// gracefully, e.g. accept legacy unsigned cursors during a rollout while
+// requiring signatures on secure ones:
+//
+//	f, err := paginate.InspectCursor(cursor)
+//	if err == nil && f.Version == 0 {
+//		data, err = paginate.DecodeCursor[any](cursor)
+//	} else {
+//		data, err = paginate.DecodeSecureCursor[any](cursor, key, paginate.SecureCursorOptions{Sign: true})
+//	}
+//
+// The result is untrusted until the cursor has been decoded. Returns zero
+// features for an empty cursor, or ErrInvalidCursor if it is malformed.
+func InspectCursor(cursor string) (CursorFeatures, error) {
+	b, err := DecodeCursorRaw(cursor)
+	if err != nil || len(b) == 0 {
+		return CursorFeatures{}, err
+	}
+	header := b[0]
+	if header&secureVersionMask != secureCursorVersion {
+		return CursorFeatures{}, nil
+	}
+	return CursorFeatures{
+		Compressed: header&secureFlagCompress != 0,
+		Signed:     header&secureFlagSign != 0,
+		Encrypted:  header&secureFlagEncrypt != 0,
+		Version:    1,
+	}, nil
+}
+
+// deriveKey derives a purpose-specific subkey from key.
+func deriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// signPayload returns the HMAC-SHA256 of b.
+func signPayload(key, b []byte) []byte {
+	mac := hmac.New(sha256.New, deriveKey(key, "paginate cursor sign"))
+	mac.Write(b)
+	return mac.Sum(nil)
+}
+
+// newCursorAEAD returns the AES-256-GCM cipher for key.
+func newCursorAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveKey(key, "paginate cursor encrypt"))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealPayload encrypts body, authenticating the header as additional data.
+// The random nonce is prepended to the ciphertext.
+func sealPayload(key []byte, header byte, body []byte) ([]byte, error) {
+	aead, err := newCursorAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(body)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, body, []byte{header}), nil
+}
+
+// openPayload reverses sealPayload.
+func openPayload(key []byte, header byte, body []byte) ([]byte, error) {
+	aead, err := newCursorAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: truncated ciphertext", ErrInvalidCursor)
+	}
+	nonce, ciphertext := body[:aead.NonceSize()], body[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte{header})
+	if err != nil {
+		return nil, fmt.Errorf("%w: decryption failed", ErrInvalidCursor)
+	}
+	return plain, nil
+}
+
+// deflatePayload compresses b.
+func deflatePayload(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// inflatePayload decompresses b, rejecting payloads over maxSecurePayload.
+func inflatePayload(b []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(b))
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxSecurePayload+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: corrupt compressed payload", ErrInvalidCursor)
+	}
+	if len(out) > maxSecurePayload {
+		return nil, fmt.Errorf("%w: payload too large", ErrInvalidCursor)
+	}
+	return out, nil
+}
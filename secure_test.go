@@ -0,0 +1,168 @@
+package paginate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecureCursorRoundTrip(t *testing.T) {
+	key := []byte("secret")
+	data := &CursorData[any]{
+		ID:        "item_42",
+		Value:     strings.Repeat("x", 200),
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name string
+		opts SecureCursorOptions
+	}{
+		{"No transforms", SecureCursorOptions{}},
+		{"Compress", SecureCursorOptions{Compress: true}},
+		{"Sign", SecureCursorOptions{Sign: true}},
+		{"Encrypt", SecureCursorOptions{Encrypt: true}},
+		{"Compress and sign", SecureCursorOptions{Compress: true, Sign: true}},
+		{"All", SecureCursorOptions{Compress: true, Sign: true, Encrypt: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := EncodeSecureCursor(data, key, tt.opts)
+			if err != nil {
+				t.Fatalf("Unexpected encode error: %v", err)
+			}
+			if tt.opts.Encrypt && strings.Contains(cursor, EncodeCursorRaw([]byte("item_42"))) {
+				t.Error("Expected encrypted cursor to hide its contents")
+			}
+
+			decoded, err := DecodeSecureCursor[any](cursor, key, tt.opts)
+			if err != nil {
+				t.Fatalf("Unexpected decode error: %v", err)
+			}
+			if decoded.ID != data.ID || decoded.Value != data.Value || !decoded.Timestamp.Equal(data.Timestamp) {
+				t.Errorf("Expected %+v, got %+v", data, decoded)
+			}
+		})
+	}
+}
+
+func TestSecureCursorCompressShrinks(t *testing.T) {
+	data := &CursorData[any]{Value: strings.Repeat("abc", 100)}
+	plain, _ := EncodeSecureCursor(data, nil, SecureCursorOptions{})
+	compressed, _ := EncodeSecureCursor(data, nil, SecureCursorOptions{Compress: true})
+	if len(compressed) >= len(plain) {
+		t.Errorf("Expected compressed cursor to be shorter: %d >= %d", len(compressed), len(plain))
+	}
+}
+
+func TestDecodeSecureCursorRejects(t *testing.T) {
+	key := []byte("secret")
+	data := &CursorData[any]{ID: "42"}
+	signedOpts := SecureCursorOptions{Compress: true, Sign: true}
+	signed, _ := EncodeSecureCursor(data, key, signedOpts)
+	unsigned, _ := EncodeSecureCursor(data, key, SecureCursorOptions{Compress: true})
+	encrypted, _ := EncodeSecureCursor(data, key, SecureCursorOptions{Encrypt: true})
+	plain, _ := EncodeCursor(data)
+
+	raw, _ := DecodeCursorRaw(signed)
+	raw[len(raw)/2] ^= 0xff
+	tampered := EncodeCursorRaw(raw)
+
+	tests := []struct {
+		name   string
+		cursor string
+		key    []byte
+		opts   SecureCursorOptions
+	}{
+		{"Tampered", tampered, key, signedOpts},
+		{"Wrong key", signed, []byte("other"), signedOpts},
+		{"Missing required signature", unsigned, key, signedOpts},
+		{"Missing required encryption", signed, key, SecureCursorOptions{Encrypt: true}},
+		{"Wrong decryption key", encrypted, []byte("other"), SecureCursorOptions{}},
+		{"Plain cursor", plain, key, SecureCursorOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeSecureCursor[any](tt.cursor, tt.key, tt.opts); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEncodeSecureCursorRequiresKey(t *testing.T) {
+	if _, err := EncodeSecureCursor(&CursorData[any]{ID: "1"}, nil, SecureCursorOptions{Sign: true}); !errors.Is(err, ErrSecureCursorKey) {
+		t.Errorf("Expected ErrSecureCursorKey, got %v", err)
+	}
+}
+
+func TestCursorPaginatorWithSecureKey(t *testing.T) {
+	key := []byte("secret")
+	opts := SecureCursorOptions{Sign: true}
+	base := NewCursorWithLimit(10).WithSecureKey(key, opts)
+
+	cursor, err := base.Encode(CursorData[any]{Offset: 20})
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	if f, _ := InspectCursor(cursor); !f.Signed {
+		t.Errorf("Expected a signed cursor, got %+v", f)
+	}
+
+	c := base.WithCursor(cursor)
+	if err := c.Validate(); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+	p, err := c.ToOffsetPaginator()
+	if err != nil || p.Page != 3 {
+		t.Errorf("Expected page 3, got %v (%v)", p, err)
+	}
+
+	plain, _ := NewCursorFromOffset(20)
+	if err := base.WithCursor(plain).Validate(); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for plain cursor, got %v", err)
+	}
+	if err := NewCursor().WithCursor(cursor).Validate(); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor without key, got %v", err)
+	}
+}
+
+func TestInspectCursor(t *testing.T) {
+	key := []byte("secret")
+	data := &CursorData[any]{ID: "42"}
+	plain, _ := EncodeCursor(data)
+	signed, _ := EncodeSecureCursor(data, key, SecureCursorOptions{Sign: true})
+	all, _ := EncodeSecureCursor(data, key, SecureCursorOptions{Compress: true, Sign: true, Encrypt: true})
+	bare, _ := EncodeSecureCursor(data, key, SecureCursorOptions{})
+
+	tests := []struct {
+		name     string
+		cursor   string
+		expected CursorFeatures
+	}{
+		{"Empty", "", CursorFeatures{}},
+		{"Plain", plain, CursorFeatures{}},
+		{"Secure without transforms", bare, CursorFeatures{Version: 1}},
+		{"Signed", signed, CursorFeatures{Signed: true, Version: 1}},
+		{"All", all, CursorFeatures{Compressed: true, Signed: true, Encrypted: true, Version: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InspectCursor(tt.cursor)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := InspectCursor("!!!"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for malformed cursor, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package paginate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SortDirection is the direction of a sort column.
+type SortDirection int
+
+// Sort directions.
+const (
+	SortAsc SortDirection = iota
+	SortDesc
+)
+
+// String returns "asc" or "desc".
+func (d SortDirection) String() string {
+	if d == SortDesc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// ParseSortDirection parses a direction token such as "asc", "desc",
+// "ascending" or "descending" (case-insensitive).
+// Returns ErrInvalidSort for unrecognized tokens.
+func ParseSortDirection(s string) (SortDirection, error) {
+	switch strings.ToLower(s) {
+	case "asc", "ascending":
+		return SortAsc, nil
+	case "desc", "descending":
+		return SortDesc, nil
+	default:
+		return SortAsc, fmt.Errorf("%w: unknown direction %q", ErrInvalidSort, s)
+	}
+}
+
+// SortSeparators are the separators recognized between a field name and a
+// trailing direction token by ParseSortToken, e.g. "name:desc" or "name.desc".
+// It should only be modified during program initialization.
+var SortSeparators = []string{":", "."}
+
+// SortField is a single column of a sort order.
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// Direction returns the sort direction of the field.
+func (f SortField) Direction() SortDirection {
+	if f.Desc {
+		return SortDesc
+	}
+	return SortAsc
+}
+
+// Sort is an ordered list of sort columns, most significant first.
+type Sort []SortField
+
+// Has returns true if the sort includes the given field.
+func (s Sort) Has(field string) bool {
+	for _, f := range s {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureTieBreak returns a sort that ends with the unique field, appending it
+// in ascending order if it is not already present. Keyset pagination over a
+// non-unique column otherwise skips or repeats rows with equal sort values.
+// The input sort is not modified. KeysetFieldsFromSort applies it when
+// building keyset conditions.
+func EnsureTieBreak(sort Sort, uniqueField string) Sort {
+	if uniqueField == "" || sort.Has(uniqueField) {
+		return sort
+	}
+	result := make(Sort, len(sort), len(sort)+1)
+	copy(result, sort)
+	return append(result, SortField{Field: uniqueField})
+}
+
+// ParseSortToken parses a single sort token in any supported dialect:
+// "-field" / "+field" prefixes, or a trailing direction after one of
+// SortSeparators ("field:desc", "field.asc"). A separator followed by
+// something other than a direction is kept as part of the field name, so
+// dotted fields such as "user.name" are supported.
+// Returns ErrInvalidSort if the field name is empty.
+func ParseSortToken(tok string) (field string, desc bool, err error) {
+	tok = strings.TrimSpace(tok)
+
+	switch {
+	case strings.HasPrefix(tok, "-"):
+		field, desc = tok[1:], true
+	case strings.HasPrefix(tok, "+"):
+		field = tok[1:]
+	default:
+		field = tok
+		for _, sep := range SortSeparators {
+			i := strings.LastIndex(tok, sep)
+			if i < 0 {
+				continue
+			}
+			if dir, err := ParseSortDirection(tok[i+len(sep):]); err == nil {
+				field, desc = tok[:i], dir == SortDesc
+				break
+			}
+		}
+	}
+
+	if field == "" {
+		return "", false, fmt.Errorf("%w: empty field in %q", ErrInvalidSort, tok)
+	}
+	return field, desc, nil
+}
+
+// SortFromQuery parses the sort query parameter into a Sort. Tokens may be
+// comma-separated and/or given as repeated parameters
+// (?sort=-created_at,name:asc). See ParseSortToken for supported dialects.
+func SortFromQuery(q url.Values) (Sort, error) {
+	var sort Sort
+	for _, v := range q["sort"] {
+		for _, tok := range strings.Split(v, ",") {
+			if strings.TrimSpace(tok) == "" {
+				continue
+			}
+			field, desc, err := ParseSortToken(tok)
+			if err != nil {
+				return nil, err
+			}
+			sort = append(sort, SortField{Field: field, Desc: desc})
+		}
+	}
+	return sort, nil
+}
@@ -0,0 +1,143 @@
+package paginate
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestEnsureTieBreak(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     Sort
+		unique   string
+		expected Sort
+	}{
+		{"Appends unique field", Sort{{Field: "name"}}, "id", Sort{{Field: "name"}, {Field: "id"}}},
+		{"Already present", Sort{{Field: "id", Desc: true}, {Field: "name"}}, "id", Sort{{Field: "id", Desc: true}, {Field: "name"}}},
+		{"Empty sort", nil, "id", Sort{{Field: "id"}}},
+		{"No unique field", Sort{{Field: "name"}}, "", Sort{{Field: "name"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EnsureTieBreak(tt.sort, tt.unique)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEnsureTieBreakDoesNotModifyInput(t *testing.T) {
+	sort := make(Sort, 1, 4)
+	sort[0] = SortField{Field: "name"}
+
+	_ = EnsureTieBreak(sort, "id")
+	extended := sort[:2]
+	if extended[1].Field != "" {
+		t.Errorf("Input backing array was modified: %v", extended)
+	}
+}
+
+func TestParseSortToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		field     string
+		desc      bool
+		wantError bool
+	}{
+		{"Plain", "name", "name", false, false},
+		{"Minus prefix", "-created_at", "created_at", true, false},
+		{"Plus prefix", "+created_at", "created_at", false, false},
+		{"Colon desc", "name:desc", "name", true, false},
+		{"Colon asc", "name:asc", "name", false, false},
+		{"Dot desc", "name.desc", "name", true, false},
+		{"Uppercase", "name:DESC", "name", true, false},
+		{"Dotted field", "user.name", "user.name", false, false},
+		{"Dotted field with direction", "user.name:desc", "user.name", true, false},
+		{"Empty", "", "", false, true},
+		{"Only prefix", "-", "", false, true},
+		{"Only direction", ":desc", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, desc, err := ParseSortToken(tt.token)
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidSort) {
+					t.Errorf("Expected ErrInvalidSort, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if field != tt.field || desc != tt.desc {
+				t.Errorf("Expected (%s, %v), got (%s, %v)", tt.field, tt.desc, field, desc)
+			}
+		})
+	}
+}
+
+func TestParseSortDirection(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  SortDirection
+		wantError bool
+	}{
+		{"asc", SortAsc, false},
+		{"DESC", SortDesc, false},
+		{"descending", SortDesc, false},
+		{"up", SortAsc, true},
+	}
+
+	for _, tt := range tests {
+		dir, err := ParseSortDirection(tt.input)
+		if (err != nil) != tt.wantError {
+			t.Errorf("%s: expected error=%v, got %v", tt.input, tt.wantError, err)
+		}
+		if dir != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.input, tt.expected, dir)
+		}
+	}
+
+	if SortDesc.String() != "desc" || SortAsc.String() != "asc" {
+		t.Error("Unexpected direction strings")
+	}
+}
+
+func TestSortFromQuery(t *testing.T) {
+	q := url.Values{"sort": {"-created_at,name:asc", "score.desc"}}
+	sort, err := SortFromQuery(q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := Sort{
+		{Field: "created_at", Desc: true},
+		{Field: "name"},
+		{Field: "score", Desc: true},
+	}
+	if len(sort) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, sort)
+	}
+	for i := range sort {
+		if sort[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, sort)
+		}
+	}
+	if sort[0].Direction() != SortDesc {
+		t.Error("Expected descending direction")
+	}
+
+	if _, err := SortFromQuery(url.Values{"sort": {"name,-"}}); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package paginate
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Strategy identifies the pagination style a client used in a request.
+type Strategy int
+
+// Pagination strategies detectable from a request.
+const (
+	StrategyOffset Strategy = iota
+	StrategyCursor
+	StrategyRange
+)
+
+// String returns the name of the strategy.
+func (s Strategy) String() string {
+	switch s {
+	case StrategyOffset:
+		return "offset"
+	case StrategyCursor:
+		return "cursor"
+	case StrategyRange:
+		return "range"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler so strategies serialize by name.
+func (s Strategy) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Query parameters that identify each strategy. "limit" is accepted by both
+// offset and cursor pagination, so it does not identify either.
+var (
+	offsetParamNames = []string{"page", "page_size", "per_page", "offset"}
+	cursorParamNames = []string{"cursor", "after", "before", "first", "last"}
+)
+
+// DetectStrategy inspects the query string and Range header to determine
+// which pagination strategy the client used. Requests without pagination
+// parameters default to StrategyOffset.
+// Returns ErrMultipleStrategies if parameters of more than one strategy are present.
+func DetectStrategy(r *http.Request) (Strategy, error) {
+	q := r.URL.Query()
+	offset := hasAnyParam(q, offsetParamNames)
+	cursor := hasAnyParam(q, cursorParamNames)
+	rng := r.Header.Get("Range") != ""
+
+	count := 0
+	for _, used := range []bool{offset, cursor, rng} {
+		if used {
+			count++
+		}
+	}
+	if count > 1 {
+		return StrategyOffset, ErrMultipleStrategies
+	}
+
+	switch {
+	case rng:
+		return StrategyRange, nil
+	case cursor:
+		return StrategyCursor, nil
+	default:
+		return StrategyOffset, nil
+	}
+}
+
+// ValidateSingleStrategy returns ErrMultipleStrategies if the request carries
+// parameters of more than one pagination strategy, e.g. a Range header
+// together with ?page=2.
+func ValidateSingleStrategy(r *http.Request) error {
+	_, err := DetectStrategy(r)
+	return err
+}
+
+// AutoPaginate detects the strategy used by the request and parses it.
+// Only the paginator for the detected strategy is non-nil.
+// Returns ErrMultipleStrategies on ambiguous requests, or the parse error of
+// an invalid Range header.
+func AutoPaginate(r *http.Request) (Strategy, *Paginator, *CursorPaginator, *Range, error) {
+	strategy, err := DetectStrategy(r)
+	if err != nil {
+		return strategy, nil, nil, nil, err
+	}
+
+	switch strategy {
+	case StrategyRange:
+		rng, err := RangeFromRequest(r)
+		if err != nil {
+			return strategy, nil, nil, nil, err
+		}
+		return strategy, nil, nil, rng, nil
+	case StrategyCursor:
+		return strategy, nil, CursorFromRequest(r), nil, nil
+	default:
+		return strategy, FromRequest(r), nil, nil, nil
+	}
+}
+
+// ValidateRequest parses and validates the pagination parameters of the
+// request for the given strategy in one step. Unlike the FromRequest family,
+// which silently falls back to defaults, malformed or out-of-bounds
+// parameters are rejected with the matching sentinel error:
+//   - offset: ErrInvalidPage, ErrInvalidPageSize or ErrInvalidOffset
+//   - cursor: ErrInvalidPageSize, ErrInvalidCursor or ErrRelayArgsConflict
+//   - range: ErrInvalidRange or ErrInvalidOffset
+func ValidateRequest(r *http.Request, strategy Strategy) error {
+	q := r.URL.Query()
+
+	switch strategy {
+	case StrategyOffset:
+		if err := checkOffsetParams(q, Options{}); err != nil {
+			return err
+		}
+		return FromRequest(r).Validate()
+	case StrategyCursor:
+		for _, name := range []string{"limit", "first", "last"} {
+			if err := checkIntParam(q, name, MinPageSize, MaxPageSize, ErrInvalidPageSize); err != nil {
+				return err
+			}
+		}
+		c := CursorFromQuery(q)
+		if err := c.ValidateRelay(q); err != nil {
+			return err
+		}
+		return c.Validate()
+	case StrategyRange:
+		_, err := RangeFromRequest(r)
+		return err
+	default:
+		return fmt.Errorf("paginate: unknown strategy %v", strategy)
+	}
+}
+
+// checkOffsetParams checks the offset pagination parameters understood by
+// FromQuery against their bounds under opts.
+func checkOffsetParams(q url.Values, opts Options) error {
+	minSize := MinPageSize
+	if opts.AllowUnlimited {
+		minSize = 0
+	}
+	if err := checkIntParam(q, "page", opts.firstPage(), math.MaxInt, ErrInvalidPage); err != nil {
+		return err
+	}
+	for _, name := range []string{"page_size", "limit", "per_page"} {
+		if err := checkIntParam(q, name, minSize, MaxPageSize, ErrInvalidPageSize); err != nil {
+			return err
+		}
+	}
+	return checkIntParam(q, "offset", 0, math.MaxInt, ErrInvalidOffset)
+}
+
+// checkIntParam returns sentinel if the named parameter is present but is not
+// an integer within [lo, hi].
+func checkIntParam(q url.Values, name string, lo, hi int, sentinel error) error {
+	s := q.Get(name)
+	if s == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < lo || n > hi {
+		return fmt.Errorf("%w: %s=%q", sentinel, name, s)
+	}
+	return nil
+}
+
+// hasAnyParam returns true if any of the named parameters is present.
+func hasAnyParam(q url.Values, names []string) bool {
+	for _, name := range names {
+		if q.Has(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package paginate
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDetectStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		rangeHdr  string
+		expected  Strategy
+		wantError bool
+	}{
+		{"No params", "http://example.com", "", StrategyOffset, false},
+		{"Offset", "http://example.com?page=2&page_size=10", "", StrategyOffset, false},
+		{"Limit only", "http://example.com?limit=10", "", StrategyOffset, false},
+		{"Cursor", "http://example.com?after=abc&limit=10", "", StrategyCursor, false},
+		{"GraphQL", "http://example.com?first=10", "", StrategyCursor, false},
+		{"Range", "http://example.com", "items=0-24", StrategyRange, false},
+		{"Offset and cursor", "http://example.com?page=2&after=abc", "", StrategyOffset, true},
+		{"Range and offset", "http://example.com?page=2", "items=0-24", StrategyOffset, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.rangeHdr != "" {
+				req.Header.Set("Range", tt.rangeHdr)
+			}
+
+			strategy, err := DetectStrategy(req)
+			if tt.wantError {
+				if !errors.Is(err, ErrMultipleStrategies) {
+					t.Errorf("Expected ErrMultipleStrategies, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if strategy != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, strategy)
+			}
+		})
+	}
+}
+
+func TestValidateSingleStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		rangeHdr  string
+		wantError bool
+	}{
+		{"Offset only", "http://example.com?page=2", "", false},
+		{"Range only", "http://example.com?status=active", "items=0-9", false},
+		{"Range and offset", "http://example.com?page=2", "items=0-9", true},
+		{"Range and cursor", "http://example.com?after=abc", "items=0-9", true},
+		{"All three", "http://example.com?page=2&after=abc", "items=0-9", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.rangeHdr != "" {
+				req.Header.Set("Range", tt.rangeHdr)
+			}
+			err := ValidateSingleStrategy(req)
+			if (err != nil) != tt.wantError {
+				t.Errorf("Expected error=%v, got error=%v", tt.wantError, err)
+			}
+			if tt.wantError && !errors.Is(err, ErrMultipleStrategies) {
+				t.Errorf("Expected ErrMultipleStrategies, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAutoPaginate(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://example.com?page=3&page_size=10", nil)
+	strategy, p, c, rng, err := AutoPaginate(req)
+	if err != nil || strategy != StrategyOffset || p == nil || c != nil || rng != nil {
+		t.Fatalf("Unexpected offset result: %v %v %v %v %v", strategy, p, c, rng, err)
+	}
+	if p.Page != 3 || p.PageSize != 10 {
+		t.Errorf("Unexpected paginator: %+v", p)
+	}
+
+	req, _ = http.NewRequest("GET", "http://example.com?before=xyz&limit=5", nil)
+	strategy, p, c, rng, err = AutoPaginate(req)
+	if err != nil || strategy != StrategyCursor || p != nil || c == nil || rng != nil {
+		t.Fatalf("Unexpected cursor result: %v %v %v %v %v", strategy, p, c, rng, err)
+	}
+	if c.Cursor != "xyz" || c.Forward || c.Limit != 5 {
+		t.Errorf("Unexpected cursor paginator: %+v", c)
+	}
+
+	req, _ = http.NewRequest("GET", "http://example.com", nil)
+	req.Header.Set("Range", "items=10-19")
+	strategy, p, c, rng, err = AutoPaginate(req)
+	if err != nil || strategy != StrategyRange || p != nil || c != nil || rng == nil {
+		t.Fatalf("Unexpected range result: %v %v %v %v %v", strategy, p, c, rng, err)
+	}
+	if rng.Start != 10 || rng.End != 19 {
+		t.Errorf("Unexpected range: %+v", rng)
+	}
+
+	req.Header.Set("Range", "items=20-10")
+	if _, _, _, _, err := AutoPaginate(req); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange, got %v", err)
+	}
+
+	req, _ = http.NewRequest("GET", "http://example.com?page=1&cursor=abc", nil)
+	if _, _, _, _, err := AutoPaginate(req); !errors.Is(err, ErrMultipleStrategies) {
+		t.Errorf("Expected ErrMultipleStrategies, got %v", err)
+	}
+}
+
+func TestStrategyString(t *testing.T) {
+	tests := []struct {
+		strategy Strategy
+		expected string
+	}{
+		{StrategyOffset, "offset"},
+		{StrategyCursor, "cursor"},
+		{StrategyRange, "range"},
+		{Strategy(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if s := tt.strategy.String(); s != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, s)
+		}
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		rangeHdr  string
+		strategy  Strategy
+		wantError error
+	}{
+		{"Offset valid", "http://example.com?page=2&page_size=10", "", StrategyOffset, nil},
+		{"Offset no params", "http://example.com", "", StrategyOffset, nil},
+		{"Offset bad page", "http://example.com?page=abc", "", StrategyOffset, ErrInvalidPage},
+		{"Offset zero page", "http://example.com?page=0", "", StrategyOffset, ErrInvalidPage},
+		{"Offset huge size", "http://example.com?page_size=5000", "", StrategyOffset, ErrInvalidPageSize},
+		{"Offset negative offset", "http://example.com?offset=-5", "", StrategyOffset, ErrInvalidOffset},
+		{"Cursor valid", "http://example.com?first=10", "", StrategyCursor, nil},
+		{"Cursor bad limit", "http://example.com?limit=0", "", StrategyCursor, ErrInvalidPageSize},
+		{"Cursor malformed", "http://example.com?after=!!!", "", StrategyCursor, ErrInvalidCursor},
+		{"Cursor conflict", "http://example.com?first=10&last=10", "", StrategyCursor, ErrRelayArgsConflict},
+		{"Range valid", "http://example.com", "items=0-24", StrategyRange, nil},
+		{"Range missing", "http://example.com", "", StrategyRange, nil},
+		{"Range reversed", "http://example.com", "items=10-5", StrategyRange, ErrInvalidRange},
+		{"Range malformed", "http://example.com", "bytes", StrategyRange, ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.rangeHdr != "" {
+				req.Header.Set("Range", tt.rangeHdr)
+			}
+
+			err := ValidateRequest(req, tt.strategy)
+			if tt.wantError == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("Expected %v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package paginate
+
+import "strconv"
+
+// PaginationSummary is a flat description of a paginated request and its
+// result, suitable for structured logging or metric labels.
+type PaginationSummary struct {
+	Strategy  Strategy `json:"strategy"`
+	Page      int      `json:"page,omitempty"`
+	Offset    int64    `json:"offset"`
+	PageSize  int      `json:"page_size"`
+	Clamped   bool     `json:"clamped"`
+	Returned  int      `json:"returned"`
+	Total     int64    `json:"total"`
+	HasCursor bool     `json:"has_cursor,omitempty"`
+	HasMore   bool     `json:"has_more"`
+}
+
+// Summary summarizes an offset-paginated request. Clamped reports whether the
+// requested page lies beyond the last page for total and would be clamped by
+// Clamp. Pass UnknownTotal if no count was taken.
+func Summary(p *Paginator, total int64, returned int) PaginationSummary {
+	s := PaginationSummary{
+		Strategy: StrategyOffset,
+		Page:     p.Page,
+		Offset:   p.Offset(),
+		PageSize: p.PageSize,
+		Clamped:  p.Clamp(total).Page != p.Page,
+		Returned: returned,
+		Total:    total,
+	}
+	if total < 0 {
+		s.HasMore = !p.IsUnlimited() && returned >= p.PageSize
+	} else {
+		s.HasMore = p.HasNext(total)
+	}
+	return s
+}
+
+// CursorSummary summarizes a cursor-paginated request. The total is always
+// reported as UnknownTotal.
+func CursorSummary(c *CursorPaginator, returned int, hasMore bool) PaginationSummary {
+	return PaginationSummary{
+		Strategy:  StrategyCursor,
+		PageSize:  c.Limit,
+		Returned:  returned,
+		Total:     UnknownTotal,
+		HasCursor: c.HasCursor(),
+		HasMore:   hasMore,
+	}
+}
+
+// RangeSummary summarizes a range-paginated request. Clamped reports whether
+// the range extends past the end of the collection.
+func RangeSummary(r *Range, total int64, returned int) PaginationSummary {
+	s := PaginationSummary{
+		Strategy: StrategyRange,
+		Offset:   r.Start,
+		PageSize: int(r.Size()),
+		Returned: returned,
+		Total:    total,
+	}
+	if total >= 0 {
+		s.Clamped = r.End >= total
+		s.HasMore = r.Start+int64(returned) < total
+	} else {
+		s.HasMore = int64(returned) >= r.Size()
+	}
+	return s
+}
+
+// Labels returns the summary as string key/value pairs, e.g. for Prometheus
+// labels or log attributes. Numeric fields are reported as coarse buckets
+// ("0", "1", "2-10", "11-100", "101-1000", "1000+", or "unknown" when
+// negative) to keep label cardinality bounded; offset is omitted because it
+// is determined by page and page_size.
+func (s PaginationSummary) Labels() map[string]string {
+	return map[string]string{
+		"strategy":   s.Strategy.String(),
+		"page":       labelBucket(int64(s.Page)),
+		"page_size":  labelBucket(int64(s.PageSize)),
+		"clamped":    strconv.FormatBool(s.Clamped),
+		"returned":   labelBucket(int64(s.Returned)),
+		"total":      labelBucket(s.Total),
+		"has_cursor": strconv.FormatBool(s.HasCursor),
+		"has_more":   strconv.FormatBool(s.HasMore),
+	}
+}
+
+// labelBucket maps n to one of a fixed set of magnitude buckets.
+func labelBucket(n int64) string {
+	switch {
+	case n < 0:
+		return "unknown"
+	case n <= 1:
+		return strconv.FormatInt(n, 10)
+	case n <= 10:
+		return "2-10"
+	case n <= 100:
+		return "11-100"
+	case n <= 1000:
+		return "101-1000"
+	default:
+		return "1000+"
+	}
+}
@@ -0,0 +1,99 @@
+package paginate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		total    int64
+		returned int
+		clamped  bool
+		hasMore  bool
+	}{
+		{"First page", 1, 100, 10, false, true},
+		{"Last page", 10, 100, 10, false, false},
+		{"Beyond last page", 20, 100, 0, true, false},
+		{"Unknown total full page", 3, UnknownTotal, 10, false, true},
+		{"Unknown total short page", 3, UnknownTotal, 4, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Paginator{Page: tt.page, PageSize: 10}
+			s := Summary(p, tt.total, tt.returned)
+
+			if s.Strategy != StrategyOffset {
+				t.Errorf("Expected offset strategy, got %v", s.Strategy)
+			}
+			if s.Offset != int64((tt.page-1)*10) {
+				t.Errorf("Expected offset %d, got %d", (tt.page-1)*10, s.Offset)
+			}
+			if s.Clamped != tt.clamped {
+				t.Errorf("Expected Clamped=%v, got %v", tt.clamped, s.Clamped)
+			}
+			if s.HasMore != tt.hasMore {
+				t.Errorf("Expected HasMore=%v, got %v", tt.hasMore, s.HasMore)
+			}
+			if s.Returned != tt.returned || s.Total != tt.total {
+				t.Errorf("Unexpected counts: %+v", s)
+			}
+		})
+	}
+}
+
+func TestCursorSummary(t *testing.T) {
+	c := NewCursorWithLimit(25).WithCursor("abc")
+	s := CursorSummary(c, 25, true)
+
+	if s.Strategy != StrategyCursor || s.PageSize != 25 || !s.HasCursor || !s.HasMore {
+		t.Errorf("Unexpected summary: %+v", s)
+	}
+	if s.Total != UnknownTotal {
+		t.Errorf("Expected unknown total, got %d", s.Total)
+	}
+}
+
+func TestRangeSummary(t *testing.T) {
+	s := RangeSummary(NewRange(90, 109), 100, 10)
+	if s.Strategy != StrategyRange || s.Offset != 90 || s.PageSize != 20 {
+		t.Errorf("Unexpected summary: %+v", s)
+	}
+	if !s.Clamped {
+		t.Error("Expected range past the end to be clamped")
+	}
+	if s.HasMore {
+		t.Error("Expected no more items")
+	}
+}
+
+func TestPaginationSummaryEncoding(t *testing.T) {
+	s := Summary(&Paginator{Page: 2, PageSize: 10}, 50, 10)
+
+	labels := s.Labels()
+	if labels["strategy"] != "offset" || labels["page"] != "2-10" || labels["has_more"] != "true" {
+		t.Errorf("Unexpected labels: %v", labels)
+	}
+	if labels["total"] != "11-100" || labels["page_size"] != "2-10" {
+		t.Errorf("Expected bucketed labels, got %v", labels)
+	}
+	if _, ok := labels["offset"]; ok {
+		t.Errorf("Expected no offset label, got %v", labels)
+	}
+
+	big := Summary(&Paginator{Page: 123456, PageSize: 1}, UnknownTotal, 1)
+	if l := big.Labels(); l["page"] != "1000+" || l["total"] != "unknown" || l["returned"] != "1" {
+		t.Errorf("Unexpected labels: %v", l)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !contains(string(data), `"strategy":"offset"`) {
+		t.Errorf("Expected strategy by name, got %s", data)
+	}
+}
@@ -0,0 +1,251 @@
+package paginate
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default columns used by TimeWindow SQL helpers.
+const (
+	DefaultTimeColumn = "ts"
+	DefaultIDColumn   = "id"
+)
+
+// TimeWindow represents time-window pagination over a half-open interval
+// [Since, Until), as used by log-style APIs (?since=...&until=...&limit=N).
+// A zero Since or Until leaves that side of the window open.
+//
+// When AfterID is set, the lower bound becomes a keyset condition on
+// (Column, IDColumn): rows at exactly Since are only included if their id
+// sorts after AfterID. This breaks ties between rows sharing a timestamp.
+type TimeWindow struct {
+	Since    time.Time `json:"since,omitzero"`
+	Until    time.Time `json:"until,omitzero"`
+	Limit    int       `json:"limit"`
+	AfterID  string    `json:"after_id,omitempty"`
+	Column   string    `json:"-"`
+	IDColumn string    `json:"-"`
+}
+
+// NewTimeWindow creates a time window with the given bounds and limit.
+// The limit is clamped like CursorPaginator.WithLimit.
+func NewTimeWindow(since, until time.Time, limit int) *TimeWindow {
+	return &TimeWindow{
+		Since:    since,
+		Until:    until,
+		Limit:    NewCursorWithLimit(limit).Limit,
+		Column:   DefaultTimeColumn,
+		IDColumn: DefaultIDColumn,
+	}
+}
+
+// Next returns the window for the following page, starting just after the
+// last returned timestamp. Until and Limit are kept, so paging stays anchored
+// to the originally requested interval.
+// Rows sharing lastSeen with the last returned row are skipped; use NextAfter
+// when timestamps are not unique.
+func (w *TimeWindow) Next(lastSeen time.Time) *TimeWindow {
+	next := *w
+	next.Since = lastSeen.Add(time.Nanosecond)
+	next.AfterID = ""
+	return &next
+}
+
+// NextAfter returns the window for the following page using the last returned
+// row's timestamp and id as a keyset position, so rows sharing lastSeen are
+// neither skipped nor repeated. Until and Limit are kept.
+func (w *TimeWindow) NextAfter(lastSeen time.Time, lastID string) *TimeWindow {
+	next := *w
+	next.Since = lastSeen
+	next.AfterID = lastID
+	return &next
+}
+
+// Validate validates the time window parameters.
+func (w *TimeWindow) Validate() error {
+	if w.Limit < MinPageSize || w.Limit > MaxPageSize {
+		return ErrInvalidPageSize
+	}
+	if !w.Since.IsZero() && !w.Until.IsZero() && w.Since.After(w.Until) {
+		return fmt.Errorf("%w: since %s is after until %s", ErrInvalidTimeWindow,
+			w.Since.Format(time.RFC3339), w.Until.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// SQLClauseArgs returns a parameterized SQL clause and its arguments.
+// Example: "WHERE ts >= ? AND ts < ? ORDER BY ts LIMIT ?"
+// With AfterID set: "WHERE (ts > ? OR (ts = ? AND id > ?)) AND ts < ? ORDER BY ts, id LIMIT ?"
+func (w *TimeWindow) SQLClauseArgs() (string, []any) {
+	column := w.Column
+	if column == "" {
+		column = DefaultTimeColumn
+	}
+	idColumn := w.IDColumn
+	if idColumn == "" {
+		idColumn = DefaultIDColumn
+	}
+
+	var conds []string
+	var args []any
+	switch {
+	case !w.Since.IsZero() && w.AfterID != "":
+		conds = append(conds, fmt.Sprintf("(%s > ? OR (%s = ? AND %s > ?))", column, column, idColumn))
+		args = append(args, w.Since, w.Since, w.AfterID)
+	case !w.Since.IsZero():
+		conds = append(conds, column+" >= ?")
+		args = append(args, w.Since)
+	}
+	if !w.Until.IsZero() {
+		conds = append(conds, column+" < ?")
+		args = append(args, w.Until)
+	}
+
+	var b strings.Builder
+	if len(conds) > 0 {
+		b.WriteString("WHERE ")
+		b.WriteString(strings.Join(conds, " AND "))
+		b.WriteString(" ")
+	}
+	if w.AfterID != "" {
+		fmt.Fprintf(&b, "ORDER BY %s, %s LIMIT ?", column, idColumn)
+	} else {
+		fmt.Fprintf(&b, "ORDER BY %s LIMIT ?", column)
+	}
+	args = append(args, w.Limit)
+
+	return b.String(), args
+}
+
+// TimeWindowFromQuery parses a time window from URL query values.
+// since and until must be RFC 3339 timestamps. An invalid limit is ignored
+// and the default is used instead.
+// The window size may be given as an ISO 8601 duration (window=PT1H), which
+// completes a single bound: since+window sets until, until-window sets
+// since, and without either bound the window ends now (since = now-window,
+// until left open).
+// after_id sets AfterID, the tie-break position for rows at exactly since
+// (see NextAfter), and requires since.
+// Returns ErrInvalidTimeWindow if a timestamp or the window is malformed,
+// window is combined with both bounds, after_id is given without since, or
+// since is after until.
+func TimeWindowFromQuery(q url.Values) (*TimeWindow, error) {
+	var since, until time.Time
+	var err error
+
+	if s := q.Get("since"); s != "" {
+		if since, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			return nil, fmt.Errorf("%w: since: %v", ErrInvalidTimeWindow, err)
+		}
+	}
+	if s := q.Get("until"); s != "" {
+		if until, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			return nil, fmt.Errorf("%w: until: %v", ErrInvalidTimeWindow, err)
+		}
+	}
+
+	if s := q.Get("window"); s != "" {
+		window, err := ParseISODuration(s)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !since.IsZero() && !until.IsZero():
+			return nil, fmt.Errorf("%w: window cannot be combined with both since and until", ErrInvalidTimeWindow)
+		case !since.IsZero():
+			until = since.Add(window)
+		case !until.IsZero():
+			since = until.Add(-window)
+		default:
+			since = time.Now().UTC().Add(-window)
+		}
+	}
+
+	limit := DefaultPageSize
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	w := NewTimeWindow(since, until, limit)
+	if afterID := q.Get("after_id"); afterID != "" {
+		if since.IsZero() {
+			return nil, fmt.Errorf("%w: after_id requires since", ErrInvalidTimeWindow)
+		}
+		w.AfterID = afterID
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// ParseISODuration parses an ISO 8601 duration such as "PT1H", "P1DT2H" or
+// "PT1.5S". Only days, hours, minutes and (fractional) seconds are supported;
+// years, months and weeks are rejected as their length is ambiguous.
+// Returns ErrInvalidTimeWindow if the duration is malformed.
+func ParseISODuration(s string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.ToUpper(s), "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("%w: duration %q: must start with P", ErrInvalidTimeWindow, s)
+	}
+
+	datePart, timePart, hasTime := strings.Cut(rest, "T")
+	if hasTime && timePart == "" {
+		return 0, fmt.Errorf("%w: duration %q: empty time part", ErrInvalidTimeWindow, s)
+	}
+	if strings.ContainsAny(datePart, "YMW") {
+		return 0, fmt.Errorf("%w: duration %q: years, months and weeks are ambiguous", ErrInvalidTimeWindow, s)
+	}
+
+	days, err := parseISODurationPart(datePart, "D", []time.Duration{24 * time.Hour})
+	if err != nil {
+		return 0, fmt.Errorf("%w: duration %q: %v", ErrInvalidTimeWindow, s, err)
+	}
+	clock, err := parseISODurationPart(timePart, "HMS", []time.Duration{time.Hour, time.Minute, time.Second})
+	if err != nil {
+		return 0, fmt.Errorf("%w: duration %q: %v", ErrInvalidTimeWindow, s, err)
+	}
+	return days + clock, nil
+}
+
+// parseISODurationPart sums the components of the date or time part of an
+// ISO 8601 duration. units lists the allowed designators in the order they
+// must appear, with sizes their lengths. Only seconds may be fractional.
+func parseISODurationPart(part, units string, sizes []time.Duration) (time.Duration, error) {
+	var total time.Duration
+	next := 0
+	for part != "" {
+		i := strings.IndexFunc(part, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("expected a number in %q", part)
+		}
+		num, unit := part[:i], part[i]
+		part = part[i+1:]
+
+		pos := strings.IndexByte(units[next:], unit)
+		if pos < 0 {
+			return 0, fmt.Errorf("unexpected designator %q", unit)
+		}
+		next += pos + 1
+
+		if strings.Contains(num, ".") && unit != 'S' {
+			return 0, fmt.Errorf("only seconds may be fractional")
+		}
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, err
+		}
+		size := sizes[next-1]
+		if n > float64(math.MaxInt64)/float64(size) {
+			return 0, fmt.Errorf("duration overflows")
+		}
+		total += time.Duration(n * float64(size))
+	}
+	return total, nil
+}
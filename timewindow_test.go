@@ -0,0 +1,226 @@
+package paginate
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTimeWindowFromQuery(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedSince time.Time
+		expectedUntil time.Time
+		expectedLimit int
+		wantError     bool
+	}{
+		{"Both bounds", "since=2024-01-01T00:00:00Z&until=2024-01-02T00:00:00Z&limit=50", since, until, 50, false},
+		{"Since only", "since=2024-01-01T00:00:00Z", since, time.Time{}, DefaultPageSize, false},
+		{"No params", "", time.Time{}, time.Time{}, DefaultPageSize, false},
+		{"Invalid limit", "limit=abc", time.Time{}, time.Time{}, DefaultPageSize, false},
+		{"Invalid since", "since=yesterday", time.Time{}, time.Time{}, 0, true},
+		{"Invalid until", "until=2024-13-01", time.Time{}, time.Time{}, 0, true},
+		{"Since after until", "since=2024-01-02T00:00:00Z&until=2024-01-01T00:00:00Z", time.Time{}, time.Time{}, 0, true},
+		{"Since and window", "since=2024-01-01T00:00:00Z&window=P1D", since, until, DefaultPageSize, false},
+		{"Until and window", "until=2024-01-02T00:00:00Z&window=PT24H", since, until, DefaultPageSize, false},
+		{"Window with both bounds", "since=2024-01-01T00:00:00Z&until=2024-01-02T00:00:00Z&window=PT1H", time.Time{}, time.Time{}, 0, true},
+		{"Invalid window", "since=2024-01-01T00:00:00Z&window=P1M", time.Time{}, time.Time{}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			w, err := TimeWindowFromQuery(q)
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidTimeWindow) {
+					t.Errorf("Expected ErrInvalidTimeWindow, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !w.Since.Equal(tt.expectedSince) {
+				t.Errorf("Expected since %v, got %v", tt.expectedSince, w.Since)
+			}
+			if !w.Until.Equal(tt.expectedUntil) {
+				t.Errorf("Expected until %v, got %v", tt.expectedUntil, w.Until)
+			}
+			if w.Limit != tt.expectedLimit {
+				t.Errorf("Expected limit %d, got %d", tt.expectedLimit, w.Limit)
+			}
+		})
+	}
+}
+
+func TestTimeWindowFromQueryAfterID(t *testing.T) {
+	q, _ := url.ParseQuery("since=2024-01-01T00:00:00Z&after_id=42")
+	w, err := TimeWindowFromQuery(q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if w.AfterID != "42" {
+		t.Errorf("Expected after_id 42, got %q", w.AfterID)
+	}
+
+	q, _ = url.ParseQuery("after_id=42")
+	if _, err := TimeWindowFromQuery(q); !errors.Is(err, ErrInvalidTimeWindow) {
+		t.Errorf("Expected ErrInvalidTimeWindow for after_id without since, got %v", err)
+	}
+}
+
+func TestTimeWindowFromQueryWindowOnly(t *testing.T) {
+	q, _ := url.ParseQuery("window=PT1H")
+	before := time.Now()
+	w, err := TimeWindowFromQuery(q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if w.Since.Before(before.Add(-time.Hour)) || w.Since.After(time.Now().Add(-time.Hour)) {
+		t.Errorf("Expected since about an hour ago, got %v", w.Since)
+	}
+	if !w.Until.IsZero() {
+		t.Errorf("Expected open until, got %v", w.Until)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  time.Duration
+		wantError bool
+	}{
+		{"PT1H", time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"PT30M", 30 * time.Minute, false},
+		{"PT1.5S", 1500 * time.Millisecond, false},
+		{"P2D", 48 * time.Hour, false},
+		{"pt1h30m", 90 * time.Minute, false},
+		{"P1DT1H1M1S", 25*time.Hour + time.Minute + time.Second, false},
+		{"P1Y", 0, true},
+		{"P1M", 0, true},
+		{"P1W", 0, true},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"1H", 0, true},
+		{"PT1M1H", 0, true},
+		{"PT1.5H", 0, true},
+		{"PTH", 0, true},
+		{"PT1X", 0, true},
+		{"P99999999999D", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseISODuration(tt.input)
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidTimeWindow) {
+					t.Errorf("Expected ErrInvalidTimeWindow, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, d)
+			}
+		})
+	}
+}
+
+func TestTimeWindowSQLClauseArgs(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		window       *TimeWindow
+		expectedSQL  string
+		expectedArgs int
+	}{
+		{"Both bounds", NewTimeWindow(since, until, 50), "WHERE ts >= ? AND ts < ? ORDER BY ts LIMIT ?", 3},
+		{"Since only", NewTimeWindow(since, time.Time{}, 50), "WHERE ts >= ? ORDER BY ts LIMIT ?", 2},
+		{"Open window", NewTimeWindow(time.Time{}, time.Time{}, 50), "ORDER BY ts LIMIT ?", 1},
+		{"Custom column", &TimeWindow{Until: until, Limit: 10, Column: "created_at"}, "WHERE created_at < ? ORDER BY created_at LIMIT ?", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.window.SQLClauseArgs()
+			if sql != tt.expectedSQL {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedSQL, sql)
+			}
+			if len(args) != tt.expectedArgs {
+				t.Fatalf("Expected %d args, got %d", tt.expectedArgs, len(args))
+			}
+			if args[len(args)-1] != tt.window.Limit {
+				t.Errorf("Expected last arg to be limit %d, got %v", tt.window.Limit, args[len(args)-1])
+			}
+		})
+	}
+}
+
+func TestTimeWindowValidate(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := NewTimeWindow(since, since, 10).Validate(); err != nil {
+		t.Errorf("Expected equal bounds to be valid, got %v", err)
+	}
+	if err := (&TimeWindow{Limit: 0}).Validate(); !errors.Is(err, ErrInvalidPageSize) {
+		t.Errorf("Expected ErrInvalidPageSize, got %v", err)
+	}
+}
+
+func TestTimeWindowNext(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	lastSeen := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+
+	w := NewTimeWindow(since, until, 50)
+	next := w.Next(lastSeen)
+
+	if !next.Since.After(lastSeen) {
+		t.Errorf("Expected since after %v, got %v", lastSeen, next.Since)
+	}
+	if !next.Until.Equal(until) {
+		t.Errorf("Expected until %v to be kept, got %v", until, next.Until)
+	}
+	if next.Limit != 50 {
+		t.Errorf("Expected limit 50, got %d", next.Limit)
+	}
+	if !w.Since.Equal(since) {
+		t.Error("Next should not modify the original window")
+	}
+}
+
+func TestTimeWindowNextAfter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	lastSeen := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+
+	next := NewTimeWindow(since, until, 50).NextAfter(lastSeen, "evt_42")
+	if !next.Since.Equal(lastSeen) || next.AfterID != "evt_42" {
+		t.Errorf("Unexpected window: %+v", next)
+	}
+
+	sql, args := next.SQLClauseArgs()
+	expected := "WHERE (ts > ? OR (ts = ? AND id > ?)) AND ts < ? ORDER BY ts, id LIMIT ?"
+	if sql != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, sql)
+	}
+	if len(args) != 5 || args[2] != "evt_42" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	// Next without an id drops the keyset position
+	if plain := next.Next(lastSeen); plain.AfterID != "" {
+		t.Errorf("Expected AfterID to be cleared, got %s", plain.AfterID)
+	}
+}